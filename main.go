@@ -3,10 +3,14 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime/pprof"
+	"strconv"
+	"strings"
 
 	"github.com/docopt/docopt-go"
 
+	"jibi/pkg/disasm"
 	"jibi/pkg/jibi"
 )
 
@@ -15,15 +19,31 @@ func main() {
 		DevStatus     bool   `docopt:"--dev-status"`
 		DevMaxTicks   int    `docopt:"--dev-maxticks"`
 		DevLogInst    bool   `docopt:"--dev-loginstructions"`
+		DevLogFormat  string `docopt:"--dev-logformat"`
 		DevCpuProfile bool   `docopt:"--dev-cpuprofile"`
+		DevTrace      string `docopt:"--dev-trace"`
+		DevStrict     bool   `docopt:"--dev-strict"`
+		CycleAccurate bool   `docopt:"--cycle-accurate"`
+		Disasm        bool   `docopt:"disasm"`
+		Bank          int    `docopt:"--bank"`
+		From          string `docopt:"--from"`
 		Rom           string `docopt:"<rom>"`
 	}
 
 	usage := `usage: jibi [options] <rom>
+       jibi disasm <rom> [--bank=N] [--from=ADDR]
+disasm options:
+  --bank=N               rom bank to disassemble [default: 0]
+  --from=ADDR            start address, defaults to the start of the bank
+options:
+  --cycle-accurate       tick the clock on every cpu memory access
 dev options:
   --dev-status           show 1 second status
   --dev-maxticks=TICKS   stop after a number of cpu ticks
   --dev-loginstructions  write jibi.log for every instruction
+  --dev-logformat=FMT    jibi.log format, json or doctor [default: json]
+  --dev-trace=FILE       write a binary trace of every instruction
+  --dev-strict           log accesses to unusable memory and unmapped io
   --dev-cpuprofile       write cpu.prof for use with pprof`
 
 	opts, err := docopt.ParseDoc(usage)
@@ -51,17 +71,58 @@ dev options:
 		return
 	}
 
+	for _, issue := range jibi.NewCartridge(rom).Validate() {
+		fmt.Fprintln(os.Stderr, issue)
+	}
+
+	if config.Disasm {
+		if err := disassemble(rom, config.Bank, config.From); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// create jibi Options
 	options := jibi.Options{
-		Status:   config.DevStatus,
-		MaxTicks: config.DevMaxTicks,
-		LogInst:  config.DevLogInst,
-		Render:   true,
-		Keypad:   true,
-		Squash:   true,
+		Status:        config.DevStatus,
+		MaxTicks:      config.DevMaxTicks,
+		LogInst:       config.DevLogInst,
+		LogFormat:     config.DevLogFormat,
+		Render:        true,
+		Keypad:        true,
+		Squash:        true,
+		CycleAccurate: config.CycleAccurate,
+		TraceFile:     config.DevTrace,
+		SaveFile:      savePath(config.Rom),
+		Strict:        config.DevStrict,
 	}
 
 	// create jibi and run
 	gb := jibi.New(rom, options)
 	gb.Run()
 }
+
+// savePath returns the save file of a rom, game.gb and game.zip both save
+// to game.sav.
+func savePath(rom string) string {
+	return strings.TrimSuffix(rom, filepath.Ext(rom)) + ".sav"
+}
+
+// disassemble prints the instructions of a rom bank.
+func disassemble(rom []jibi.Byte, bank int, from string) error {
+	d := disasm.New(jibi.NewCartridge(rom))
+	start, end := disasm.Region(bank)
+	if from != "" {
+		addr, err := strconv.ParseUint(from, 0, 16)
+		if err != nil {
+			return fmt.Errorf("invalid address %s: %v", from, err)
+		}
+		start = jibi.Word(addr)
+	}
+	insts, err := d.Range(bank, start, end)
+	for _, inst := range insts {
+		fmt.Println(inst)
+	}
+	return err
+}
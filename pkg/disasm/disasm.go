@@ -0,0 +1,164 @@
+// Package disasm decodes cartridge rom into sm83 instructions using the
+// opcode table of the jibi cpu.
+package disasm
+
+import (
+	"fmt"
+	"strings"
+
+	"jibi/pkg/jibi"
+)
+
+// rom layout as seen by the cpu
+const (
+	bankSize  = 0x4000
+	bankStart = jibi.Word(0x4000) // start of the switchable bank
+	romEnd    = 0x8000
+)
+
+// An Instruction is a single decoded instruction.
+type Instruction struct {
+	Addr     jibi.Word // cpu address
+	Bank     int
+	Bytes    []jibi.Byte
+	Mnemonic string // from the opcode table, e.g. "JR NZ, *"
+	Text     string // mnemonic with resolved operands, e.g. "JR NZ, 0x0150"
+	Illegal  bool   // undefined opcode or truncated operands, shown as data
+}
+
+func (i Instruction) String() string {
+	bs := ""
+	for _, b := range i.Bytes {
+		bs += fmt.Sprintf("%02X ", b)
+	}
+	return fmt.Sprintf("%02X:%04X  %-9s %s", i.Bank, i.Addr, bs, i.Text)
+}
+
+// A Disassembler decodes instructions from the rom of a Cartridge.
+type Disassembler struct {
+	rom []jibi.Byte
+}
+
+// New returns a Disassembler for the cartridge rom.
+func New(cart *jibi.Cartridge) *Disassembler {
+	return &Disassembler{cart.Rom}
+}
+
+// Banks returns the number of 16 KB rom banks.
+func (d *Disassembler) Banks() int {
+	return (len(d.rom) + bankSize - 1) / bankSize
+}
+
+// Region returns the cpu address range [from, to) a bank is mapped to. Bank 0
+// is the fixed bank, every other bank is seen through the switchable bank.
+func Region(bank int) (from, to jibi.Word) {
+	if bank == 0 {
+		return 0x0000, bankStart
+	}
+	return bankStart, romEnd
+}
+
+// offset translates a cpu address in bank to an offset in the rom.
+func (d *Disassembler) offset(bank int, addr jibi.Word) (int, error) {
+	if bank < 0 || bank >= d.Banks() {
+		return 0, fmt.Errorf("bank %d out of range, rom has %d banks",
+			bank, d.Banks())
+	}
+	from, to := Region(bank)
+	if int(addr) < int(from) || int(addr) >= int(to) {
+		return 0, fmt.Errorf("address 0x%04X is outside bank %d", addr, bank)
+	}
+	return bank*bankSize + int(addr-from), nil
+}
+
+// Decode decodes the instruction at addr in bank. Operands never cross the
+// end of the bank, an instruction that would is returned as data.
+func (d *Disassembler) Decode(bank int, addr jibi.Word) (Instruction, error) {
+	off, err := d.offset(bank, addr)
+	if err != nil {
+		return Instruction{}, err
+	}
+	from, to := Region(bank)
+	end := bank*bankSize + int(to-from)
+	if end > len(d.rom) {
+		end = len(d.rom)
+	}
+	if off >= end {
+		return Instruction{}, fmt.Errorf("address 0x%04X is past the end of the rom", addr)
+	}
+
+	inst := Instruction{Addr: addr, Bank: bank}
+	op := d.rom[off]
+	n := 1
+	info, ok := jibi.LookupOpcode(op, false)
+	if op == 0xCB && off+1 < end {
+		info, ok = jibi.LookupOpcode(d.rom[off+1], true)
+		n = 2
+	}
+	if ok {
+		n += int(info.Operands)
+	}
+	if !ok || off+n > end {
+		inst.Bytes = d.rom[off : off+1]
+		inst.Text = fmt.Sprintf("DB 0x%02X", op)
+		inst.Illegal = true
+		return inst, nil
+	}
+	inst.Bytes = d.rom[off : off+n]
+	inst.Mnemonic = info.Mnemonic
+	inst.Text = resolve(addr, inst.Bytes, info.Mnemonic)
+	return inst, nil
+}
+
+// Range decodes the instructions in bank starting at from and stopping
+// before to, from has to be in the region of the bank.
+func (d *Disassembler) Range(bank int, from, to jibi.Word) ([]Instruction, error) {
+	if start, end := Region(bank); from < start || from >= end {
+		return nil, fmt.Errorf("address 0x%04X outside bank %d region 0x%04X-0x%04X",
+			from, bank, start, end-1)
+	}
+	var insts []Instruction
+	for addr := int(from); addr < int(to); {
+		inst, err := d.Decode(bank, jibi.Word(addr))
+		if err != nil {
+			return insts, err
+		}
+		insts = append(insts, inst)
+		addr += len(inst.Bytes)
+	}
+	return insts, nil
+}
+
+// resolve replaces the immediate placeholders of mnemonic with the operand
+// values, jump offsets become absolute addresses.
+func resolve(addr jibi.Word, bs []jibi.Byte, mnemonic string) string {
+	switch len(bs) {
+	case 2:
+		if bs[0] == 0xCB || bs[0] == 0x10 { // STOP ignores its operand
+			return mnemonic
+		}
+		n := bs[1]
+		switch bs[0] {
+		case 0x18, 0x20, 0x28, 0x30, 0x38: // JR
+			target := addr + 2 + jibi.Word(int8(n))
+			return replaceLast(mnemonic, fmt.Sprintf("0x%04X", target))
+		case 0xE8, 0xF8: // ADD SP, n and LDHL SP, n
+			return replaceLast(mnemonic, fmt.Sprintf("%d", int8(n)))
+		case 0xE0, 0xF0: // LDH
+			return strings.Replace(mnemonic, "(n)",
+				fmt.Sprintf("(0x%04X)", 0xFF00+jibi.Word(n)), 1)
+		}
+		return replaceLast(mnemonic, fmt.Sprintf("0x%02X", n))
+	case 3:
+		w := jibi.BytesToWord(bs[2], bs[1])
+		return strings.Replace(mnemonic, "nn", fmt.Sprintf("0x%04X", w), 1)
+	}
+	return mnemonic
+}
+
+// replaceLast replaces the last word of mnemonic, where single byte
+// immediates always live.
+func replaceLast(mnemonic, s string) string {
+	i := strings.LastIndex(mnemonic, " ")
+	return mnemonic[:i+1] + s
+}
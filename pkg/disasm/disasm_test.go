@@ -0,0 +1,101 @@
+package disasm
+
+import (
+	"testing"
+
+	"jibi/pkg/jibi"
+)
+
+func testCartridge() *jibi.Cartridge {
+	rom := make([]jibi.Byte, 0x8000)
+	copy(rom[0x0100:], []jibi.Byte{
+		0x00,             // NOP
+		0xC3, 0x50, 0x01, // JP nn
+	})
+	copy(rom[0x0150:], []jibi.Byte{
+		0x3E, 0x42, // LD A, #
+		0xE0, 0x44, // LDH (n), A
+		0xCB, 0x37, // SWAP A
+		0x20, 0xFE, // JR NZ, *
+		0xF8, 0xFE, // LDHL SP, n
+		0xD3, // illegal
+	})
+	rom[0x7FFE] = 0x01 // LD BC, nn cut off by the end of the bank
+	return jibi.NewCartridge(rom)
+}
+
+func TestRange(t *testing.T) {
+	d := New(testCartridge())
+
+	expected := []string{
+		"NOP",
+		"JP 0x0150",
+	}
+	insts, err := d.Range(0, 0x0100, 0x0104)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(insts) != len(expected) {
+		t.Fatal(insts)
+	}
+	for i, inst := range insts {
+		if inst.Text != expected[i] {
+			t.Error(inst)
+		}
+	}
+
+	expected = []string{
+		"LD A, 0x42",
+		"LDH (0xFF44), A",
+		"SWAP A",
+		"JR NZ, 0x0156",
+		"LDHL SP, -2",
+		"DB 0xD3",
+	}
+	insts, err = d.Range(0, 0x0150, 0x015B)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(insts) != len(expected) {
+		t.Fatal(insts)
+	}
+	for i, inst := range insts {
+		if inst.Text != expected[i] {
+			t.Error(inst)
+		}
+	}
+	if !insts[5].Illegal || insts[4].Illegal {
+		t.Error()
+	}
+	if s := insts[0].String(); s != "00:0150  3E 42     LD A, 0x42" {
+		t.Error(s)
+	}
+
+	// a start outside the bank is an error rather than nothing
+	_, err = d.Range(0, 0x7000, 0x4000)
+	if err == nil || err.Error() != "address 0x7000 outside bank 0 region 0x0000-0x3FFF" {
+		t.Error(err)
+	}
+	if _, err := d.Range(1, 0x0100, 0x8000); err == nil {
+		t.Error("address outside bank 1")
+	}
+}
+
+func TestDecodeBank(t *testing.T) {
+	d := New(testCartridge())
+
+	inst, err := d.Decode(1, 0x7FFE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !inst.Illegal || inst.Text != "DB 0x01" {
+		t.Error(inst)
+	}
+
+	if _, err := d.Decode(1, 0x3FFF); err == nil {
+		t.Error("address outside the bank")
+	}
+	if _, err := d.Decode(d.Banks(), 0x4000); err == nil {
+		t.Error("bank outside the rom")
+	}
+}
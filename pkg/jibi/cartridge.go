@@ -7,44 +7,273 @@ import (
 // A Cartridge holds the game rom as well as information about the rom
 // capabilities.
 type Cartridge struct {
-	Rom []Byte
+	Rom    []Byte // as seen through the mbc, see romImage
+	romLen int    // size of the dump
 
 	// rom info
-	name    string
-	color   bool
-	super   bool
-	ct      cartridgeType
-	romSize cartridgeRomSize
-	ramSize cartridgeRamSize
+	name         string
+	manufacturer string // 4 letter code on newer cartridges
+	color        bool
+	super        bool
+	ct           cartridgeType
+	romSize      cartridgeRomSize
+	ramSize      cartridgeRamSize
+	licensee     licensee
+	destination  Byte // 0x00 Japan, 0x01 overseas
+	version      Byte // mask rom version
+
+	// header values and the ones computed from the rom
+	logo           bool // the logo matches the one the boot rom checks
+	headerChecksum Byte
+	headerSum      Byte
+	globalChecksum Word
+	globalSum      Word
+
+	mbc Mbc
 }
 
+// nintendoLogo is the boot rom's copy of the logo that every cartridge
+// header must repeat.
+var nintendoLogo = bios[0x00A8:0x00D8]
+
 // NewCartridge reads and parses a rom and returns a new cartridge object.
-func NewCartridge(rom []Byte) *Cartridge {
-	name := ""
-	for _, c := range rom[0x0134 : 0x0142+1] {
+func NewCartridge(dump []Byte) *Cartridge {
+	rom := romImage(dump)
+	cart := &Cartridge{
+		Rom:         rom,
+		romLen:      len(dump),
+		color:       rom[0x0143]&0x80 != 0,
+		super:       rom[0x0146] == 0x03,
+		ct:          cartridgeType(rom[0x0147]),
+		romSize:     cartridgeRomSize(rom[0x0148]),
+		ramSize:     cartridgeRamSize(rom[0x0149]),
+		licensee:    licensee{rom[0x014B], string(rune(rom[0x0144])) + string(rune(rom[0x0145]))},
+		destination: rom[0x014A],
+		version:     rom[0x014C],
+
+		headerChecksum: rom[0x014D],
+		globalChecksum: BytesToWord(rom[0x014E], rom[0x014F]),
+	}
+
+	// the title shrank to make room for the manufacturer code and the color
+	// flag, the code is only there when it looks like one
+	title := rom[0x0134:0x0143]
+	if code := rom[0x013F:0x0143]; cart.color && isManufacturer(code) {
+		title = rom[0x0134:0x013F]
+		cart.manufacturer = string([]byte{byte(code[0]), byte(code[1]),
+			byte(code[2]), byte(code[3])})
+	}
+	for _, c := range title {
 		if c == 0 {
 			break
 		}
-		name += string(c)
-	}
-	romN := make([]Byte, 0x10000)
-	copy(romN, rom)
-	color := rom[0x0143] == 0x80
-	super := rom[0x0146] == 0x03
-	ct := cartridgeType(rom[0x0147])
-	romSize := cartridgeRomSize(rom[0x0148])
-	ramSize := cartridgeRamSize(rom[0x0149])
-	cart := &Cartridge{romN, name, color, super, ct, romSize, ramSize}
+		cart.name += string(rune(c))
+	}
+
+	cart.logo = true
+	for i, b := range nintendoLogo {
+		if rom[0x0104+i] != b {
+			cart.logo = false
+		}
+	}
+	for _, b := range rom[0x0134:0x014D] {
+		cart.headerSum = cart.headerSum - b - 1
+	}
+	for i, b := range dump {
+		if i != 0x014E && i != 0x014F {
+			cart.globalSum += Word(b)
+		}
+	}
+	cart.mbc = newMbc(cart.ct, cart.Rom, cart.ramSize.bytes())
 	return cart
 }
 
+// romImage returns the rom as the mbc sees it, a power of two in size so bank
+// numbers can be masked. A truncated dump is padded with 0xFF, and a dump
+// smaller than the header rom size or 32 KB is mirrored like a smaller chip
+// whose upper address lines are not connected.
+func romImage(dump []Byte) []Byte {
+	size := 1
+	for size < len(dump) {
+		size <<= 1
+	}
+	rom := make([]Byte, size)
+	copy(rom, dump)
+	for i := len(dump); i < size; i++ {
+		rom[i] = 0xFF
+	}
+	want := 0x8000
+	if len(rom) > 0x0148 {
+		if n := cartridgeRomSize(rom[0x0148]).banks() * 0x4000; n > want {
+			want = n
+		}
+	}
+	for len(rom) < want {
+		rom = append(rom, rom...)
+	}
+	return rom
+}
+
+// isPowerOfTwo returns true for 1, 2, 4, ...
+func isPowerOfTwo(n int) bool {
+	return n > 0 && n&(n-1) == 0
+}
+
+// isManufacturer returns true for 4 upper case letters or digits.
+func isManufacturer(code []Byte) bool {
+	for _, c := range code {
+		if !(c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// An IssueLevel tells how bad a HeaderIssue is.
+type IssueLevel int
+
+// Header issue levels.
+const (
+	// IssueWarning is a mismatch real hardware ignores.
+	IssueWarning IssueLevel = iota
+	// IssueError stops the boot rom or leaves the cartridge unsupported.
+	IssueError
+)
+
+func (l IssueLevel) String() string {
+	if l == IssueError {
+		return "error"
+	}
+	return "warning"
+}
+
+// A HeaderIssue is a problem found in the cartridge header.
+type HeaderIssue struct {
+	Level IssueLevel
+	Msg   string
+}
+
+func (i HeaderIssue) String() string {
+	return fmt.Sprintf("%s: %s", i.Level, i.Msg)
+}
+
+// Validate checks the header against the rom and against what is supported
+// and returns the problems found, errors first.
+func (c *Cartridge) Validate() []HeaderIssue {
+	var errs, warnings []HeaderIssue
+	errorf := func(format string, a ...interface{}) {
+		errs = append(errs, HeaderIssue{IssueError, fmt.Sprintf(format, a...)})
+	}
+	warnf := func(format string, a ...interface{}) {
+		warnings = append(warnings, HeaderIssue{IssueWarning, fmt.Sprintf(format, a...)})
+	}
+
+	if !c.logo {
+		errorf("nintendo logo does not match, the boot rom would lock up")
+	}
+	if c.headerChecksum != c.headerSum {
+		errorf("header checksum is 0x%02X, computed 0x%02X, the boot rom would lock up",
+			c.headerChecksum, c.headerSum)
+	}
+	if !c.ct.supported() {
+		errorf("cartridge type %s is not supported", c.ct)
+	}
+	if c.romSize.banks() == 0 {
+		errorf("unknown rom size 0x%02X", uint8(c.romSize))
+	}
+	if c.ramSize.bytes() == 0 && uint8(c.ramSize) != 0x00 {
+		errorf("unknown ram size 0x%02X", uint8(c.ramSize))
+	}
+	if max := c.ct.maxRomBanks(); max > 0 && c.romLen > max*0x4000 {
+		errorf("rom is 0x%X bytes, %s maps at most 0x%X", c.romLen, c.ct, max*0x4000)
+	}
+
+	// a dump that is not a whole chip was cut short, the missing part is
+	// unknown
+	header := c.romSize.banks() * 0x4000
+	switch {
+	case header == 0 || c.romLen == header:
+	case c.romLen < header && !isPowerOfTwo(c.romLen):
+		warnf("rom is 0x%X bytes but the header says 0x%X, padded with 0xFF",
+			c.romLen, header)
+	case c.romLen < header:
+		warnf("rom is 0x%X bytes but the header says 0x%X, mirrored",
+			c.romLen, header)
+	default:
+		warnf("rom is 0x%X bytes but the header says 0x%X", c.romLen, header)
+	}
+	if c.globalChecksum != c.globalSum {
+		warnf("global checksum is 0x%04X, computed 0x%04X",
+			c.globalChecksum, c.globalSum)
+	}
+	if c.ct.ram() && c.ramSize == 0x00 {
+		warnf("cartridge type %s has ram but the ram size is 0", c.ct)
+	}
+	if !c.ct.ram() && c.ramSize != 0x00 {
+		warnf("cartridge type %s has no ram but the ram size is %s", c.ct, c.ramSize)
+	}
+	if c.destination > 0x01 {
+		warnf("unknown destination 0x%02X", c.destination)
+	}
+	if c.licensee.String() == "" {
+		warnf("unknown licensee %s", c.licensee.code())
+	}
+	return append(errs, warnings...)
+}
+
+// BatteryRam returns the ram kept by the cartridge battery, or nil for
+// cartridges without one.
+func (c *Cartridge) BatteryRam() BatteryRam {
+	if !c.ct.battery() {
+		return nil
+	}
+	br, _ := c.mbc.(BatteryRam)
+	return br
+}
+
+// SetTimeSource makes the cartridge clock, if it has one, run on now instead
+// of the system time.
+func (c *Cartridge) SetTimeSource(now TimeSource) {
+	if m, ok := c.mbc.(*mbc3); ok && m.rtc != nil {
+		m.rtc.now = now
+		m.rtc.last = now()
+	}
+}
+
+// AttachRumble returns a channel that gets an event when the rumble motor
+// turns on or off, or nil for cartridges without a motor.
+func (c *Cartridge) AttachRumble() chan RumbleEvent {
+	if m, ok := c.mbc.(*mbc5); ok && m.rumble {
+		return m.attachRumble()
+	}
+	return nil
+}
+
 func (c *Cartridge) String() string {
+	destination := "Japan"
+	switch c.destination {
+	case 0x00:
+	case 0x01:
+		destination = "Overseas"
+	default:
+		destination = fmt.Sprintf("%02X-UNKNOWN", c.destination)
+	}
 	return fmt.Sprintf(`name: %s
+manufacturer: %s
+licensee: %s %s
+destination: %s
+version: %d
 romSize: %s
 ramSize: %s
 color: %v
 super: %v
-type: %s`, c.name, c.romSize, c.ramSize, c.color, c.super, c.ct)
+type: %s
+logo: %v
+headerChecksum: %02X (computed %02X)
+globalChecksum: %04X (computed %04X)`,
+		c.name, c.manufacturer, c.licensee.code(), c.licensee, destination,
+		c.version, c.romSize, c.ramSize, c.color, c.super, c.ct, c.logo,
+		c.headerChecksum, c.headerSum, c.globalChecksum, c.globalSum)
 }
 
 type cartridgeType uint8
@@ -111,6 +340,52 @@ func (ct cartridgeType) String() string {
 	}
 }
 
+// battery returns true for cartridge types that keep their ram powered.
+func (ct cartridgeType) battery() bool {
+	switch ct {
+	case 0x03, 0x06, 0x09, 0x0D, 0x0F, 0x10, 0x13, 0x1B, 0x1E:
+		return true
+	}
+	return false
+}
+
+// supported returns true for cartridge types with a memory bank controller.
+func (ct cartridgeType) supported() bool {
+	switch ct {
+	case 0x00, 0x01, 0x02, 0x03, 0x05, 0x06, 0x08, 0x09,
+		0x0F, 0x10, 0x11, 0x12, 0x13, 0x19, 0x1A, 0x1B, 0x1C, 0x1D, 0x1E:
+		return true
+	}
+	return false
+}
+
+// maxRomBanks returns the number of 16 KB banks the controller can map, or 0
+// for unsupported types.
+func (ct cartridgeType) maxRomBanks() int {
+	switch ct {
+	case 0x00, 0x08, 0x09:
+		return 2
+	case 0x01, 0x02, 0x03, 0x0F, 0x10, 0x11, 0x12, 0x13:
+		return 128
+	case 0x05, 0x06:
+		return 16
+	case 0x19, 0x1A, 0x1B, 0x1C, 0x1D, 0x1E:
+		return 512
+	}
+	return 0
+}
+
+// ram returns true for cartridge types with external ram. MBC2 ram is built
+// into the controller and not counted.
+func (ct cartridgeType) ram() bool {
+	switch ct {
+	case 0x02, 0x03, 0x08, 0x09, 0x0C, 0x0D, 0x10, 0x12, 0x13,
+		0x1A, 0x1B, 0x1D, 0x1E:
+		return true
+	}
+	return false
+}
+
 type cartridgeRomSize uint8
 
 func (cs cartridgeRomSize) banks() int {
@@ -129,6 +404,10 @@ func (cs cartridgeRomSize) banks() int {
 		return 64
 	case 0x06:
 		return 128
+	case 0x07:
+		return 256
+	case 0x08:
+		return 512
 	case 0x52:
 		return 72
 	case 0x53:
@@ -146,23 +425,29 @@ func (cs cartridgeRomSize) String() string {
 
 type cartridgeRamSize uint8
 
+// banks returns the number of 8 KB ram banks, the 2 KB size counts as one.
 func (cs cartridgeRamSize) banks() int {
+	return (cs.bytes() + 0x1FFF) / 0x2000
+}
+
+// bytes returns the size of the external ram.
+func (cs cartridgeRamSize) bytes() int {
 	switch cs {
-	case 0x00:
-		return 0
 	case 0x01:
-		return 1
+		return 0x0800
 	case 0x02:
-		return 2
+		return 0x2000
 	case 0x03:
-		return 4
+		return 0x8000
 	case 0x04:
-		return 16
+		return 0x20000
+	case 0x05:
+		return 0x10000
 	}
 	return 0
 }
 
 func (cs cartridgeRamSize) String() string {
 	return fmt.Sprintf("%02X-%dKbit,%dKByte,%dbanks",
-		uint8(cs), cs.banks()*128, cs.banks()*16, cs.banks())
+		uint8(cs), cs.bytes()/128, cs.bytes()/1024, cs.banks())
 }
@@ -0,0 +1,173 @@
+package jibi
+
+import (
+	"strings"
+	"testing"
+)
+
+// newHeaderRom returns a 32 KB rom with a valid header.
+func newHeaderRom() []Byte {
+	rom := make([]Byte, 0x8000)
+	copy(rom[0x0104:], nintendoLogo)
+	for i, c := range "TITLEGAMEXA" {
+		rom[0x0134+i] = Byte(c)
+	}
+	rom[0x0143] = 0x80 // color
+	rom[0x0144] = '0'
+	rom[0x0145] = '1'
+	rom[0x0147] = 0x13 // MBC3+RAM+BATT
+	rom[0x0148] = 0x00
+	rom[0x0149] = 0x03
+	rom[0x014A] = 0x01
+	rom[0x014B] = 0x33
+	rom[0x014C] = 0x02
+	fixChecksums(rom)
+	return rom
+}
+
+func fixChecksums(rom []Byte) {
+	rom[0x014D] = 0
+	for _, b := range rom[0x0134:0x014D] {
+		rom[0x014D] = rom[0x014D] - b - 1
+	}
+	sum := Word(0)
+	for i, b := range rom {
+		if i != 0x014E && i != 0x014F {
+			sum += Word(b)
+		}
+	}
+	rom[0x014E], rom[0x014F] = sum.High(), sum.Low()
+}
+
+func TestCartridgeHeader(t *testing.T) {
+	cart := NewCartridge(newHeaderRom())
+	if issues := cart.Validate(); len(issues) != 0 {
+		t.Error(issues)
+	}
+	if cart.name != "TITLEGAMEXA" || cart.manufacturer != "" {
+		t.Error(cart.name, cart.manufacturer)
+	}
+	for _, want := range []string{
+		"name: TITLEGAMEXA",
+		"licensee: 01 Nintendo R&D1",
+		"destination: Overseas",
+		"version: 2",
+		"ramSize: 03-256Kbit,32KByte,4banks",
+		"logo: true",
+	} {
+		if s := cart.String(); !strings.Contains(s, want) {
+			t.Errorf("%q not in\n%s", want, s)
+		}
+	}
+
+	// the code is only split off when it looks like one
+	rom := newHeaderRom()
+	for i, c := range "BXTE" {
+		rom[0x013F+i] = Byte(c)
+	}
+	cart = NewCartridge(rom)
+	if cart.name != "TITLEGAMEXA" || cart.manufacturer != "BXTE" {
+		t.Error(cart.name, cart.manufacturer)
+	}
+
+	rom = newHeaderRom()
+	rom[0x014B] = 0xA4
+	if l := NewCartridge(rom).licensee; l.code() != "A4" || l.String() != "Konami" {
+		t.Error(l.code(), l)
+	}
+}
+
+func TestCartridgeValidate(t *testing.T) {
+	levels := func(rom []Byte) string {
+		s := ""
+		for _, issue := range NewCartridge(rom).Validate() {
+			s += issue.Level.String()[:1]
+		}
+		return s
+	}
+
+	rom := newHeaderRom()
+	rom[0x0110] ^= 0xFF
+	fixChecksums(rom)
+	if l := levels(rom); l != "e" {
+		t.Error("logo", l)
+	}
+
+	rom = newHeaderRom()
+	rom[0x014D]++
+	if l := levels(rom); l != "ew" {
+		t.Error("header checksum", l)
+	}
+
+	rom = newHeaderRom()
+	rom[0x014E]++
+	if l := levels(rom); l != "w" {
+		t.Error("global checksum", l)
+	}
+
+	rom = newHeaderRom()
+	rom[0x0147] = 0xFE // HuC3
+	fixChecksums(rom)
+	if l := levels(rom); l != "ew" {
+		t.Error("type", l)
+	}
+
+	rom = newHeaderRom()
+	rom[0x0149] = 0x00
+	fixChecksums(rom)
+	if l := levels(rom); l != "w" {
+		t.Error("ram size", l)
+	}
+}
+
+func TestCartridgeRomSize(t *testing.T) {
+	// the full image is kept
+	rom := newBankedRom(256, 0x19, 0x00)
+	rom[0x0148] = 0x07
+	cart := NewCartridge(rom)
+	if len(cart.Rom) != 0x400000 || cart.Rom[255*0x4000] != 0xFF || cart.Rom[255*0x4000+1] != 0x00 {
+		t.Error(len(cart.Rom))
+	}
+
+	// a small chip is mirrored
+	rom = newHeaderRom()
+	rom[0x0148] = 0x02
+	rom[0x4000] = 0x42
+	fixChecksums(rom)
+	cart = NewCartridge(rom)
+	if len(cart.Rom) != 0x20000 || cart.Rom[0x1C000] != 0x42 {
+		t.Error(len(cart.Rom))
+	}
+	issues := cart.Validate()
+	if len(issues) != 1 || !strings.Contains(issues[0].Msg, "mirrored") {
+		t.Error(issues)
+	}
+
+	// a truncated dump is padded
+	rom = newHeaderRom()[:0x6000]
+	cart = NewCartridge(rom)
+	if len(cart.Rom) != 0x8000 || cart.Rom[0x6000] != 0xFF {
+		t.Error(len(cart.Rom))
+	}
+	issues = cart.Validate()
+	if len(issues) != 1 || !strings.Contains(issues[0].Msg, "padded") {
+		t.Error(issues)
+	}
+
+	// more rom than the controller can map
+	rom = newBankedRom(4, 0x00, 0x00)
+	rom[0x0148] = 0x01
+	issues = NewCartridge(rom).Validate()
+	found := false
+	for _, issue := range issues {
+		found = found || issue.Level == IssueError && strings.Contains(issue.Msg, "maps at most")
+	}
+	if !found {
+		t.Error(issues)
+	}
+
+	// too short for a header
+	if cart := NewCartridge(nil); len(cart.Rom) != 0x8000 {
+		t.Error(len(cart.Rom))
+	}
+}
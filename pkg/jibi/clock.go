@@ -7,6 +7,7 @@ type ClockType uint8
 
 type Clock struct {
 	dests    []chan ClockType
+	funcs    []func(ClockType)
 	attacher chan chan ClockType
 }
 
@@ -22,6 +23,12 @@ func (c *Clock) Attach() chan ClockType {
 	return dest
 }
 
+// AttachFunc calls f with every cycle count on the goroutine that adds them.
+// It is not safe to call once the clock is running.
+func (c *Clock) AttachFunc(f func(ClockType)) {
+	c.funcs = append(c.funcs, f)
+}
+
 // Send cycle count to all destinations
 func (c *Clock) AddCycles(cycles ClockType) {
 	// attach all pending
@@ -34,6 +41,9 @@ func (c *Clock) AddCycles(cycles ClockType) {
 		}
 	}
 	// broadcast to all destinations
+	for _, f := range c.funcs {
+		f(cycles)
+	}
 	for _, d := range c.dests {
 		d <- cycles
 	}
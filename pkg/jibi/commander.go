@@ -14,7 +14,10 @@ const (
 	CmdUnloadBios
 	CmdSetInterrupt
 	CmdClockAccumulator // accumulating clock
-	CmdOnInstruction    // blocking clock channel that ticks after every instruction
+	CmdOnInstruction    // Tracer that gets a record of every instruction
+	CmdGetRegisters     // snapshot of the cpu registers
+	CmdSetRegisters     // overwrite the cpu registers
+	CmdGetBatteryRam    // copy of the battery backed cartridge ram
 	cmdCPU
 
 	CmdFrameCounter
@@ -44,6 +47,12 @@ func (c Command) String() string {
 		return "CmdClockAccumulator"
 	case CmdOnInstruction:
 		return "CmdOnInstruction"
+	case CmdGetRegisters:
+		return "CmdGetRegisters"
+	case CmdSetRegisters:
+		return "CmdSetRegisters"
+	case CmdGetBatteryRam:
+		return "CmdGetBatteryRam"
 	case cmdCPU:
 		return "cmdCPU"
 	case CmdFrameCounter:
@@ -90,6 +99,7 @@ type CommanderStateFn func(bool, uint32) (CommanderStateFn, bool, uint32, uint32
 type CommanderInterface interface {
 	RunCommand(Command, interface{})
 	start(CommanderStateFn, map[Command]CommandFn, chan ClockType)
+	advance(uint32)
 	yield()
 	play()
 	pause()
@@ -105,23 +115,56 @@ type Commander struct {
 	playing      bool
 	running      bool
 	handlerFns   map[Command]CommandFn
+
+	// a synchronous commander has no goroutine, commands are processed as
+	// they are sent and advance runs the state function
+	sync     bool
+	state    CommanderStateFn
+	first    bool
+	t, tnext uint32
 }
 
 // NewCommander returns a new named Commander object.
 func NewCommander(name string) *Commander {
-	c := &Commander{name,
-		make(chan CommandResponse, 1024), // HACK
-		nil, nil, false, false, nil,
+	c := &Commander{name: name,
+		c: make(chan CommandResponse, 1024), // HACK
 	}
 	return c
 }
 
-// start creates the goroutine.
+// NewSyncCommander returns a new named Commander that runs on the caller's
+// goroutine.
+func NewSyncCommander(name string) *Commander {
+	c := NewCommander(name)
+	c.sync = true
+	return c
+}
+
+// start creates the goroutine, a synchronous commander only keeps the state.
 func (c *Commander) start(state CommanderStateFn, handlerFns map[Command]CommandFn, clk chan ClockType) {
 	c.handlerFns = handlerFns
+	if c.sync {
+		c.state = state
+		c.first = true
+		c.running = true
+		return
+	}
 	go c.loopCommander(state, clk)
 }
 
+// advance gives a synchronous commander t more cycles and runs the state
+// function for as long as they last, the same way loopCommander does.
+func (c *Commander) advance(t uint32) {
+	if !c.playing || !c.running || c.state == nil {
+		c.t = 0
+		return
+	}
+	c.t += t
+	for c.state != nil && (c.t >= c.tnext || c.first) {
+		c.state, c.first, c.t, c.tnext = c.state(c.first, c.t)
+	}
+}
+
 // yield gives the commander an opportunity to process any pending commands
 // TODO: verify that there is 0 change of deadlock after a yield
 func (c *Commander) yield() {
@@ -130,6 +173,10 @@ func (c *Commander) yield() {
 
 // RunCommand queues the given command for processing.
 func (c *Commander) RunCommand(cmd Command, resp interface{}) {
+	if c.sync {
+		c.processCommand(CommandResponse{cmd, resp})
+		return
+	}
 	c.c <- CommandResponse{cmd, resp}
 }
 
@@ -18,7 +18,7 @@ func (c command) String() string {
 type opcode uint16
 
 func (o opcode) String() string {
-	if c, ok := commandTable[o]; ok {
+	if c := lookupCommand(o); c != nil {
 		if len(c.s) > 0 {
 			return c.s
 		}
@@ -26,7 +26,46 @@ func (o opcode) String() string {
 	return fmt.Sprintf("0x%02X", uint16(o))
 }
 
-var commandTable = map[opcode]command{
+// lookupCommand returns the command for a base or 0xCB00+n opcode, or nil
+// if the opcode is not defined.
+func lookupCommand(o opcode) *command {
+	var cmd *command
+	if o <= 0xFF {
+		cmd = &commandTable[o]
+	} else if o&0xFF00 == 0xCB00 {
+		cmd = &cbCommandTable[o&0xFF]
+	}
+	if cmd == nil || cmd.f == nil {
+		return nil
+	}
+	return cmd
+}
+
+// An OpcodeInfo describes an opcode for tools outside the cpu, such as a
+// disassembler. The mnemonic marks immediates with # and n for a byte, * for a
+// signed jump offset and nn for a word.
+type OpcodeInfo struct {
+	Mnemonic string
+	Operands uint8     // number of immediate bytes
+	Cycles   ClockType // clock cycles, not taken for conditionals
+}
+
+// LookupOpcode returns the OpcodeInfo for the base opcode b, or for 0xCB b
+// when cb is set. It returns false for undefined opcodes.
+func LookupOpcode(b Byte, cb bool) (OpcodeInfo, bool) {
+	o := opcode(b)
+	if cb {
+		o |= 0xCB00
+	}
+	cmd := lookupCommand(o)
+	if cmd == nil {
+		return OpcodeInfo{}, false
+	}
+	return OpcodeInfo{cmd.s, cmd.b, cmd.t}, true
+}
+
+// commandTable holds the base opcodes, undefined opcodes have a nil f.
+var commandTable = [256]command{
 	0x00: command{"NOP", 0, 4, func(*Cpu) {}},
 	0x01: command{"LD BC, nn", 2, 12, func(c *Cpu) {
 		c.b.setWord(BytesToWord(c.inst.p[1], c.inst.p[0]))
@@ -48,71 +87,82 @@ var commandTable = map[opcode]command{
 	}},
 	0x07: command{"RLCA", 0, 4, func(c *Cpu) {
 		c.a.set(c.rlc(c.a.Byte()))
+		c.f.resetFlag(flagZ)
 	}},
 	0x08: command{"LD (nn), SP", 2, 20, func(c *Cpu) {
-		panic("untested")
 		c.writeWord(BytesToWord(c.inst.p[1], c.inst.p[0]), c.sp)
 	}},
+	0x09: command{"ADD HL, BC", 0, 8, func(c *Cpu) {
+		c.h.setWord(c.addWord(c.h.Word(), c.b.Word()))
+	}},
+	0x0A: command{"LD A, (BC)", 0, 8, func(c *Cpu) {
+		c.a.set(c.readByte(c.b.Word()))
+	}},
 	0x0B: command{"DEC BC", 0, 8, func(c *Cpu) {
-		panic("untested")
 		c.b.setWord(c.b.Word() - 1)
 	}},
 	0x0C: command{"INC C", 0, 4, func(c *Cpu) {
 		c.c.set(c.inc(c.c.Byte()))
 	}},
 	0x0D: command{"DEC C", 0, 4, func(c *Cpu) {
-		// panic("untested") XXX
 		c.c.set(c.dec(c.c.Byte()))
 	}},
 	0x0E: command{"LD C, #", 1, 8, func(c *Cpu) {
 		c.c.set(c.inst.p[0])
 	}},
+	0x0F: command{"RRCA", 0, 4, func(c *Cpu) {
+		c.a.set(c.rrc(c.a.Byte()))
+		c.f.resetFlag(flagZ)
+	}},
+	0x10: command{"STOP", 1, 4, func(c *Cpu) {
+		c.stop()
+	}},
 	0x11: command{"LD DE, nn", 2, 12, func(c *Cpu) {
 		c.d.setWord(BytesToWord(c.inst.p[1], c.inst.p[0]))
 	}},
 	0x12: command{"LD (DE), A", 0, 8, func(c *Cpu) {
-		panic("untested")
 		c.writeByte(c.d.Word(), c.a.Byte())
 	}},
 	0x13: command{"INC DE", 0, 8, func(c *Cpu) {
 		c.d.setWord(c.d.Word() + 1)
 	}},
 	0x14: command{"INC D", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.d.set(c.inc(c.d.Byte()))
 	}},
 	0x15: command{"DEC D", 0, 4, func(c *Cpu) {
-		// panic("untested") XXX
 		c.d.set(c.dec(c.d.Byte()))
 	}},
 	0x16: command{"LD D, #", 1, 8, func(c *Cpu) {
-		// panic("untested") XXX
 		c.d.set(c.inst.p[0])
 	}},
 	0x17: command{"RLA", 0, 4, func(c *Cpu) {
 		c.a.set(c.rl(c.a.Byte()))
+		c.f.resetFlag(flagZ)
 	}},
-	0x18: command{"JR n", 1, 8, func(c *Cpu) {
+	0x18: command{"JR n", 1, 12, func(c *Cpu) {
 		c.jr(int8(c.inst.p[0]))
 	}},
+	0x19: command{"ADD HL, DE", 0, 8, func(c *Cpu) {
+		c.h.setWord(c.addWord(c.h.Word(), c.d.Word()))
+	}},
 	0x1A: command{"LD A, (DE)", 0, 8, func(c *Cpu) {
 		c.a.set(c.readByte(c.d.Word()))
 	}},
+	0x1B: command{"DEC DE", 0, 8, func(c *Cpu) {
+		c.d.setWord(c.d.Word() - 1)
+	}},
 	0x1C: command{"INC E", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.e.set(c.inc(c.e.Byte()))
 	}},
 	0x1D: command{"DEC E", 0, 4, func(c *Cpu) {
-		// panic("untested") XXX
 		c.e.set(c.dec(c.e.Byte()))
 	}},
 	0x1E: command{"LD E, #", 1, 8, func(c *Cpu) {
-		// panic("untested")
 		c.e.set(c.inst.p[0])
 	}},
 	0x1F: command{"RRA", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.rr(c.a.Byte()))
+		c.f.resetFlag(flagZ)
 	}},
 	0x20: command{"JR NZ, *", 1, 8, func(c *Cpu) {
 		c.jrNF(flagZ, int8(c.inst.p[0]))
@@ -128,49 +178,47 @@ var commandTable = map[opcode]command{
 		c.h.setWord(c.h.Word() + 1)
 	}},
 	0x24: command{"INC H", 0, 4, func(c *Cpu) {
-		// panic("untested") XXX
 		c.h.set(c.inc(c.h.Byte()))
 	}},
 	0x25: command{"DEC H", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.h.set(c.dec(c.h.Byte()))
 	}},
 	0x26: command{"LD H, #", 1, 8, func(c *Cpu) {
-		panic("untested")
 		c.h.set(c.inst.p[0])
 	}},
 	0x27: command{"DAA", 0, 4, func(c *Cpu) {
-		panic("untested")
-		a := c.a.Byte()
-		if a&0x0F > 9 || c.f.getFlag(flagH) {
-			a += 0x06
-			c.f.setFlag(flagH)
-		}
-		if a > 0x9F || c.f.getFlag(flagC) {
-			a += 0x60
-			c.f.setFlag(flagC)
-		}
+		c.a.set(c.daa(c.a.Byte()))
 	}},
 	0x28: command{"JR Z, *", 1, 8, func(c *Cpu) {
 		c.jrF(flagZ, int8(c.inst.p[0]))
 	}},
+	0x29: command{"ADD HL, HL", 0, 8, func(c *Cpu) {
+		c.h.setWord(c.addWord(c.h.Word(), c.h.Word()))
+	}},
 	0x2A: command{"LDI A, (HL)", 0, 8, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.readByte(c.h.Word()))
 		c.h.setWord(c.h.Word() + 1)
 	}},
+	0x2B: command{"DEC HL", 0, 8, func(c *Cpu) {
+		c.h.setWord(c.h.Word() - 1)
+	}},
 	0x2C: command{"INC L", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.l.set(c.inc(c.l.Byte()))
 	}},
 	0x2D: command{"DEC L", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.l.set(c.dec(c.l.Byte()))
 	}},
 	0x2E: command{"LD L, #", 1, 8, func(c *Cpu) {
-		// panic("untested") XXX
 		c.l.set(c.inst.p[0])
 	}},
+	0x2F: command{"CPL", 0, 4, func(c *Cpu) {
+		c.a.set(c.a.Byte() ^ 0xFF)
+		c.f.setFlag(flagN)
+		c.f.setFlag(flagH)
+	}},
+	0x30: command{"JR NC, *", 1, 8, func(c *Cpu) {
+		c.jrNF(flagC, int8(c.inst.p[0]))
+	}},
 	0x31: command{"LD SP, nn", 2, 12, func(c *Cpu) {
 		c.sp = register16(BytesToWord(c.inst.p[1], c.inst.p[0]))
 	}},
@@ -178,383 +226,1393 @@ var commandTable = map[opcode]command{
 		c.writeByte(c.h.Word(), c.a.Byte())
 		c.h.setWord(c.h.Word() - 1)
 	}},
+	0x33: command{"INC SP", 0, 8, func(c *Cpu) {
+		c.sp++
+	}},
 	0x34: command{"INC (HL)", 0, 12, func(c *Cpu) {
-		panic("untested")
 		v := c.readByte(c.h.Word())
 		v = c.inc(v)
 		c.writeByte(c.h.Word(), v)
 	}},
 	0x35: command{"DEC (HL)", 0, 12, func(c *Cpu) {
-		panic("untested")
 		v := c.readByte(c.h.Word())
 		v = c.dec(v)
 		c.writeByte(c.h.Word(), v)
 	}},
-	0x36: command{"LD (HL), n", 1, 12, func(c *Cpu) {
-		panic("untested")
+	0x36: command{"LD (HL), #", 1, 12, func(c *Cpu) {
 		c.writeByte(c.h.Word(), c.inst.p[0])
 	}},
+	0x37: command{"SCF", 0, 4, func(c *Cpu) {
+		c.f.resetFlag(flagN)
+		c.f.resetFlag(flagH)
+		c.f.setFlag(flagC)
+	}},
+	0x38: command{"JR C, *", 1, 8, func(c *Cpu) {
+		c.jrF(flagC, int8(c.inst.p[0]))
+	}},
+	0x39: command{"ADD HL, SP", 0, 8, func(c *Cpu) {
+		c.h.setWord(c.addWord(c.h.Word(), c.sp))
+	}},
 	0x3A: command{"LDD A, (HL)", 0, 8, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.readByte(c.h.Word()))
 		c.h.setWord(c.h.Word() - 1)
 	}},
+	0x3B: command{"DEC SP", 0, 8, func(c *Cpu) {
+		c.sp--
+	}},
+	0x3C: command{"INC A", 0, 4, func(c *Cpu) {
+		c.a.set(c.inc(c.a.Byte()))
+	}},
 	0x3D: command{"DEC A", 0, 4, func(c *Cpu) {
-		// panic("untested") XXX
 		c.a.set(c.dec(c.a.Byte()))
 	}},
 	0x3E: command{"LD A, #", 1, 8, func(c *Cpu) {
 		c.a.set(c.inst.p[0])
 	}},
+	0x3F: command{"CCF", 0, 4, func(c *Cpu) {
+		c.f.resetFlag(flagN)
+		c.f.resetFlag(flagH)
+		if c.f.getFlag(flagC) {
+			c.f.resetFlag(flagC)
+		} else {
+			c.f.setFlag(flagC)
+		}
+	}},
 	0x40: command{"LD B, B", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.b.set(c.b.Byte())
 	}},
 	0x41: command{"LD B, C", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.b.set(c.c.Byte())
 	}},
 	0x42: command{"LD B, D", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.b.set(c.d.Byte())
 	}},
 	0x43: command{"LD B, E", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.b.set(c.e.Byte())
 	}},
 	0x44: command{"LD B, H", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.b.set(c.h.Byte())
 	}},
 	0x45: command{"LD B, L", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.b.set(c.l.Byte())
 	}},
 	0x46: command{"LD B, (HL)", 0, 8, func(c *Cpu) {
-		panic("untested")
 		c.b.set(c.readByte(c.h.Word()))
 	}},
 	0x47: command{"LD B, A", 0, 4, func(c *Cpu) {
-		// panic("untested") XXX
 		c.b.set(c.a.Byte())
 	}},
+	0x48: command{"LD C, B", 0, 4, func(c *Cpu) {
+		c.c.set(c.b.Byte())
+	}},
+	0x49: command{"LD C, C", 0, 4, func(c *Cpu) {
+		c.c.set(c.c.Byte())
+	}},
+	0x4A: command{"LD C, D", 0, 4, func(c *Cpu) {
+		c.c.set(c.d.Byte())
+	}},
+	0x4B: command{"LD C, E", 0, 4, func(c *Cpu) {
+		c.c.set(c.e.Byte())
+	}},
+	0x4C: command{"LD C, H", 0, 4, func(c *Cpu) {
+		c.c.set(c.h.Byte())
+	}},
+	0x4D: command{"LD C, L", 0, 4, func(c *Cpu) {
+		c.c.set(c.l.Byte())
+	}},
+	0x4E: command{"LD C, (HL)", 0, 8, func(c *Cpu) {
+		c.c.set(c.readByte(c.h.Word()))
+	}},
 	0x4F: command{"LD C, A", 0, 4, func(c *Cpu) {
 		c.c.set(c.a.Byte())
 	}},
+	0x50: command{"LD D, B", 0, 4, func(c *Cpu) {
+		c.d.set(c.b.Byte())
+	}},
+	0x51: command{"LD D, C", 0, 4, func(c *Cpu) {
+		c.d.set(c.c.Byte())
+	}},
+	0x52: command{"LD D, D", 0, 4, func(c *Cpu) {
+		c.d.set(c.d.Byte())
+	}},
+	0x53: command{"LD D, E", 0, 4, func(c *Cpu) {
+		c.d.set(c.e.Byte())
+	}},
+	0x54: command{"LD D, H", 0, 4, func(c *Cpu) {
+		c.d.set(c.h.Byte())
+	}},
+	0x55: command{"LD D, L", 0, 4, func(c *Cpu) {
+		c.d.set(c.l.Byte())
+	}},
+	0x56: command{"LD D, (HL)", 0, 8, func(c *Cpu) {
+		c.d.set(c.readByte(c.h.Word()))
+	}},
 	0x57: command{"LD D, A", 0, 4, func(c *Cpu) {
-		// panic("untested") XXX
 		c.d.set(c.a.Byte())
 	}},
+	0x58: command{"LD E, B", 0, 4, func(c *Cpu) {
+		c.e.set(c.b.Byte())
+	}},
+	0x59: command{"LD E, C", 0, 4, func(c *Cpu) {
+		c.e.set(c.c.Byte())
+	}},
+	0x5A: command{"LD E, D", 0, 4, func(c *Cpu) {
+		c.e.set(c.d.Byte())
+	}},
+	0x5B: command{"LD E, E", 0, 4, func(c *Cpu) {
+		c.e.set(c.e.Byte())
+	}},
+	0x5C: command{"LD E, H", 0, 4, func(c *Cpu) {
+		c.e.set(c.h.Byte())
+	}},
+	0x5D: command{"LD E, L", 0, 4, func(c *Cpu) {
+		c.e.set(c.l.Byte())
+	}},
+	0x5E: command{"LD E, (HL)", 0, 8, func(c *Cpu) {
+		c.e.set(c.readByte(c.h.Word()))
+	}},
 	0x5F: command{"LD E, A", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.e.set(c.a.Byte())
 	}},
+	0x60: command{"LD H, B", 0, 4, func(c *Cpu) {
+		c.h.set(c.b.Byte())
+	}},
+	0x61: command{"LD H, C", 0, 4, func(c *Cpu) {
+		c.h.set(c.c.Byte())
+	}},
+	0x62: command{"LD H, D", 0, 4, func(c *Cpu) {
+		c.h.set(c.d.Byte())
+	}},
+	0x63: command{"LD H, E", 0, 4, func(c *Cpu) {
+		c.h.set(c.e.Byte())
+	}},
+	0x64: command{"LD H, H", 0, 4, func(c *Cpu) {
+		c.h.set(c.h.Byte())
+	}},
+	0x65: command{"LD H, L", 0, 4, func(c *Cpu) {
+		c.h.set(c.l.Byte())
+	}},
+	0x66: command{"LD H, (HL)", 0, 8, func(c *Cpu) {
+		c.h.set(c.readByte(c.h.Word()))
+	}},
 	0x67: command{"LD H, A", 0, 4, func(c *Cpu) {
-		// panic("untested") XXX
 		c.h.set(c.a.Byte())
 	}},
+	0x68: command{"LD L, B", 0, 4, func(c *Cpu) {
+		c.l.set(c.b.Byte())
+	}},
+	0x69: command{"LD L, C", 0, 4, func(c *Cpu) {
+		c.l.set(c.c.Byte())
+	}},
+	0x6A: command{"LD L, D", 0, 4, func(c *Cpu) {
+		c.l.set(c.d.Byte())
+	}},
+	0x6B: command{"LD L, E", 0, 4, func(c *Cpu) {
+		c.l.set(c.e.Byte())
+	}},
+	0x6C: command{"LD L, H", 0, 4, func(c *Cpu) {
+		c.l.set(c.h.Byte())
+	}},
+	0x6D: command{"LD L, L", 0, 4, func(c *Cpu) {
+		c.l.set(c.l.Byte())
+	}},
+	0x6E: command{"LD L, (HL)", 0, 8, func(c *Cpu) {
+		c.l.set(c.readByte(c.h.Word()))
+	}},
 	0x6F: command{"LD L, A", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.l.set(c.a.Byte())
 	}},
+	0x70: command{"LD (HL), B", 0, 8, func(c *Cpu) {
+		c.writeByte(c.h.Word(), c.b.Byte())
+	}},
+	0x71: command{"LD (HL), C", 0, 8, func(c *Cpu) {
+		c.writeByte(c.h.Word(), c.c.Byte())
+	}},
+	0x72: command{"LD (HL), D", 0, 8, func(c *Cpu) {
+		c.writeByte(c.h.Word(), c.d.Byte())
+	}},
 	0x73: command{"LD (HL), E", 0, 8, func(c *Cpu) {
-		panic("untested")
 		c.writeByte(c.h.Word(), c.e.Byte())
 	}},
+	0x74: command{"LD (HL), H", 0, 8, func(c *Cpu) {
+		c.writeByte(c.h.Word(), c.h.Byte())
+	}},
+	0x75: command{"LD (HL), L", 0, 8, func(c *Cpu) {
+		c.writeByte(c.h.Word(), c.l.Byte())
+	}},
+	0x76: command{"HALT", 0, 4, func(c *Cpu) {
+		c.halt()
+	}},
 	0x77: command{"LD (HL), A", 0, 8, func(c *Cpu) {
 		c.writeByte(c.h.Word(), c.a.Byte())
 	}},
 	0x78: command{"LD A, B", 0, 4, func(c *Cpu) {
-		// panic("untested") XXX
 		c.a.set(c.b.Byte())
 	}},
 	0x79: command{"LD A, C", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.c.Byte())
 	}},
 	0x7A: command{"LD A, D", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.d.Byte())
 	}},
 	0x7B: command{"LD A, E", 0, 4, func(c *Cpu) {
-		// panic("untested") XXX
 		c.a.set(c.e.Byte())
 	}},
 	0x7C: command{"LD A, H", 0, 4, func(c *Cpu) {
-		// panic("untested") XXX
 		c.a.set(c.h.Byte())
 	}},
 	0x7D: command{"LD A, L", 0, 4, func(c *Cpu) {
-		// panic("untested") XXX
 		c.a.set(c.l.Byte())
 	}},
 	0x7E: command{"LD A, (HL)", 0, 8, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.readByte(c.h.Word()))
 	}},
 	0x7F: command{"LD A, A", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.a.Byte())
 	}},
 	0x80: command{"ADD A, B", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.add(c.a.Byte(), c.b.Byte()))
 	}},
 	0x81: command{"ADD A, C", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.add(c.a.Byte(), c.c.Byte()))
 	}},
 	0x82: command{"ADD A, D", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.add(c.a.Byte(), c.d.Byte()))
 	}},
 	0x83: command{"ADD A, E", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.add(c.a.Byte(), c.e.Byte()))
 	}},
 	0x84: command{"ADD A, H", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.add(c.a.Byte(), c.h.Byte()))
 	}},
 	0x85: command{"ADD A, L", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.add(c.a.Byte(), c.l.Byte()))
 	}},
 	0x86: command{"ADD A, (HL)", 0, 8, func(c *Cpu) {
-		// panic("untested") XXX
 		c.a.set(c.add(c.a.Byte(), c.readByte(c.h.Word())))
 	}},
 	0x87: command{"ADD A, A", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.add(c.a.Byte(), c.a.Byte()))
 	}},
 	0x88: command{"ADC A, B", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.adc(c.a.Byte(), c.b.Byte()))
 	}},
 	0x89: command{"ADC A, C", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.adc(c.a.Byte(), c.c.Byte()))
 	}},
 	0x8A: command{"ADC A, D", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.adc(c.a.Byte(), c.d.Byte()))
 	}},
 	0x8B: command{"ADC A, E", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.adc(c.a.Byte(), c.e.Byte()))
 	}},
 	0x8C: command{"ADC A, H", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.adc(c.a.Byte(), c.h.Byte()))
 	}},
 	0x8D: command{"ADC A, L", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.adc(c.a.Byte(), c.l.Byte()))
 	}},
 	0x8E: command{"ADC A, (HL)", 0, 8, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.adc(c.a.Byte(), c.readByte(c.h.Word())))
 	}},
 	0x8F: command{"ADC A, A", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.adc(c.a.Byte(), c.a.Byte()))
 	}},
 	0x90: command{"SUB B", 0, 4, func(c *Cpu) {
-		// panic("untested") XXX
 		c.a.set(c.sub(c.a.Byte(), c.b.Byte()))
 	}},
 	0x91: command{"SUB C", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.sub(c.a.Byte(), c.c.Byte()))
 	}},
 	0x92: command{"SUB D", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.sub(c.a.Byte(), c.d.Byte()))
 	}},
 	0x93: command{"SUB E", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.sub(c.a.Byte(), c.e.Byte()))
 	}},
 	0x94: command{"SUB H", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.sub(c.a.Byte(), c.h.Byte()))
 	}},
 	0x95: command{"SUB L", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.sub(c.a.Byte(), c.l.Byte()))
 	}},
 	0x96: command{"SUB (HL)", 0, 8, func(c *Cpu) {
-		panic("untested")
-		v := c.readByte(c.h.Word())
-		c.a.set(c.sub(c.a.Byte(), v))
+		c.a.set(c.sub(c.a.Byte(), c.readByte(c.h.Word())))
 	}},
 	0x97: command{"SUB A", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.sub(c.a.Byte(), c.a.Byte()))
 	}},
+	0x98: command{"SBC A, B", 0, 4, func(c *Cpu) {
+		c.a.set(c.sbc(c.a.Byte(), c.b.Byte()))
+	}},
+	0x99: command{"SBC A, C", 0, 4, func(c *Cpu) {
+		c.a.set(c.sbc(c.a.Byte(), c.c.Byte()))
+	}},
+	0x9A: command{"SBC A, D", 0, 4, func(c *Cpu) {
+		c.a.set(c.sbc(c.a.Byte(), c.d.Byte()))
+	}},
+	0x9B: command{"SBC A, E", 0, 4, func(c *Cpu) {
+		c.a.set(c.sbc(c.a.Byte(), c.e.Byte()))
+	}},
+	0x9C: command{"SBC A, H", 0, 4, func(c *Cpu) {
+		c.a.set(c.sbc(c.a.Byte(), c.h.Byte()))
+	}},
+	0x9D: command{"SBC A, L", 0, 4, func(c *Cpu) {
+		c.a.set(c.sbc(c.a.Byte(), c.l.Byte()))
+	}},
+	0x9E: command{"SBC A, (HL)", 0, 8, func(c *Cpu) {
+		c.a.set(c.sbc(c.a.Byte(), c.readByte(c.h.Word())))
+	}},
+	0x9F: command{"SBC A, A", 0, 4, func(c *Cpu) {
+		c.a.set(c.sbc(c.a.Byte(), c.a.Byte()))
+	}},
+	0xA0: command{"AND B", 0, 4, func(c *Cpu) {
+		c.a.set(c.and(c.a.Byte(), c.b.Byte()))
+	}},
+	0xA1: command{"AND C", 0, 4, func(c *Cpu) {
+		c.a.set(c.and(c.a.Byte(), c.c.Byte()))
+	}},
+	0xA2: command{"AND D", 0, 4, func(c *Cpu) {
+		c.a.set(c.and(c.a.Byte(), c.d.Byte()))
+	}},
+	0xA3: command{"AND E", 0, 4, func(c *Cpu) {
+		c.a.set(c.and(c.a.Byte(), c.e.Byte()))
+	}},
 	0xA4: command{"AND H", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.and(c.a.Byte(), c.h.Byte()))
 	}},
+	0xA5: command{"AND L", 0, 4, func(c *Cpu) {
+		c.a.set(c.and(c.a.Byte(), c.l.Byte()))
+	}},
+	0xA6: command{"AND (HL)", 0, 8, func(c *Cpu) {
+		c.a.set(c.and(c.a.Byte(), c.readByte(c.h.Word())))
+	}},
+	0xA7: command{"AND A", 0, 4, func(c *Cpu) {
+		c.a.set(c.and(c.a.Byte(), c.a.Byte()))
+	}},
 	0xA8: command{"XOR B", 0, 4, func(c *Cpu) {
 		c.a.set(c.xor(c.a.Byte(), c.b.Byte()))
 	}},
 	0xA9: command{"XOR C", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.xor(c.a.Byte(), c.c.Byte()))
 	}},
 	0xAA: command{"XOR D", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.xor(c.a.Byte(), c.d.Byte()))
 	}},
 	0xAB: command{"XOR E", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.xor(c.a.Byte(), c.e.Byte()))
 	}},
 	0xAC: command{"XOR H", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.xor(c.a.Byte(), c.h.Byte()))
 	}},
 	0xAD: command{"XOR L", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.xor(c.a.Byte(), c.l.Byte()))
 	}},
 	0xAE: command{"XOR (HL)", 0, 8, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.xor(c.a.Byte(), c.readByte(c.h.Word())))
 	}},
 	0xAF: command{"XOR A", 0, 4, func(c *Cpu) {
 		c.a.set(c.xor(c.a.Byte(), c.a.Byte()))
 	}},
 	0xB0: command{"OR B", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.or(c.a.Byte(), c.b.Byte()))
 	}},
 	0xB1: command{"OR C", 0, 4, func(c *Cpu) {
 		c.a.set(c.or(c.a.Byte(), c.c.Byte()))
 	}},
 	0xB2: command{"OR D", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.or(c.a.Byte(), c.d.Byte()))
 	}},
 	0xB3: command{"OR E", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.or(c.a.Byte(), c.e.Byte()))
 	}},
 	0xB4: command{"OR H", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.or(c.a.Byte(), c.h.Byte()))
 	}},
 	0xB5: command{"OR L", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.or(c.a.Byte(), c.l.Byte()))
 	}},
 	0xB6: command{"OR (HL)", 0, 8, func(c *Cpu) {
-		panic("untested")
 		c.a.set(c.or(c.a.Byte(), c.readByte(c.h.Word())))
 	}},
+	0xB7: command{"OR A", 0, 4, func(c *Cpu) {
+		c.a.set(c.or(c.a.Byte(), c.a.Byte()))
+	}},
 	0xB8: command{"CP B", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.sub(c.a.Byte(), c.b.Byte())
 	}},
 	0xB9: command{"CP C", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.sub(c.a.Byte(), c.c.Byte())
 	}},
 	0xBA: command{"CP D", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.sub(c.a.Byte(), c.d.Byte())
 	}},
 	0xBB: command{"CP E", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.sub(c.a.Byte(), c.e.Byte())
 	}},
 	0xBC: command{"CP H", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.sub(c.a.Byte(), c.h.Byte())
 	}},
 	0xBD: command{"CP L", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.sub(c.a.Byte(), c.l.Byte())
 	}},
 	0xBE: command{"CP (HL)", 0, 8, func(c *Cpu) {
-		// panic("untested") XXX
-		v := c.readByte(c.h.Word())
-		c.sub(c.a.Byte(), v)
+		c.sub(c.a.Byte(), c.readByte(c.h.Word()))
 	}},
 	0xBF: command{"CP A", 0, 4, func(c *Cpu) {
-		panic("untested")
 		c.sub(c.a.Byte(), c.a.Byte())
 	}},
+	0xC0: command{"RET NZ", 0, 8, func(c *Cpu) {
+		c.retNF(flagZ)
+	}},
 	0xC1: command{"POP BC", 0, 12, func(c *Cpu) {
 		c.b.setWord(c.pop())
 	}},
-	0xC3: command{"JP nn", 2, 12, func(c *Cpu) {
-		// panic("untested") XXX
+	0xC2: command{"JP NZ, nn", 2, 12, func(c *Cpu) {
+		c.jpNF(flagZ, BytesToWord(c.inst.p[1], c.inst.p[0]))
+	}},
+	0xC3: command{"JP nn", 2, 16, func(c *Cpu) {
 		c.jp(BytesToWord(c.inst.p[1], c.inst.p[0]))
 	}},
+	0xC4: command{"CALL NZ, nn", 2, 12, func(c *Cpu) {
+		c.callNF(flagZ, BytesToWord(c.inst.p[1], c.inst.p[0]))
+	}},
 	0xC5: command{"PUSH BC", 0, 16, func(c *Cpu) {
 		c.push(c.b.Word())
 	}},
-	0xC9: command{"RET", 0, 8, func(c *Cpu) {
-		c.jp(c.pop())
+	0xC6: command{"ADD A, #", 1, 8, func(c *Cpu) {
+		c.a.set(c.add(c.a.Byte(), c.inst.p[0]))
 	}},
-	0xCB01: command{"RLC C", 0, 8, func(c *Cpu) {
-		panic("untested")
-		c.c.set(c.rlc(c.c.Byte()))
+	0xC7: command{"RST 00H", 0, 16, func(c *Cpu) {
+		c.rst(0x0000)
 	}},
-	0xCB11: command{"RL C", 0, 8, func(c *Cpu) {
-		c.c.set(c.rl(c.c.Byte()))
+	0xC8: command{"RET Z", 0, 8, func(c *Cpu) {
+		c.retF(flagZ)
 	}},
-	0xCB7C: command{"BIT 7, H", 0, 8, func(c *Cpu) {
-		// 0xCB40 + 8*b(7) + r(H, 4)
-		c.bit(7, c.h.Byte())
+	0xC9: command{"RET", 0, 16, func(c *Cpu) {
+		c.jp(c.pop())
 	}},
-	0xCB87: command{"RES 0, A", 0, 8, func(c *Cpu) {
-		// 0xCB80 + 8*b(0) + r(A, 7)
-		c.a.set(c.res(0, c.a.Byte()))
+	0xCA: command{"JP Z, nn", 2, 12, func(c *Cpu) {
+		c.jpF(flagZ, BytesToWord(c.inst.p[1], c.inst.p[0]))
 	}},
 	0xCC: command{"CALL Z, nn", 2, 12, func(c *Cpu) {
-		panic("untested")
 		c.callF(flagZ, BytesToWord(c.inst.p[1], c.inst.p[0]))
 	}},
-	0xCD: command{"CALL nn", 2, 12, func(c *Cpu) {
+	0xCD: command{"CALL nn", 2, 24, func(c *Cpu) {
 		c.call(BytesToWord(c.inst.p[1], c.inst.p[0]))
 	}},
+	0xCE: command{"ADC A, #", 1, 8, func(c *Cpu) {
+		c.a.set(c.adc(c.a.Byte(), c.inst.p[0]))
+	}},
+	0xCF: command{"RST 08H", 0, 16, func(c *Cpu) {
+		c.rst(0x0008)
+	}},
+	0xD0: command{"RET NC", 0, 8, func(c *Cpu) {
+		c.retNF(flagC)
+	}},
+	0xD1: command{"POP DE", 0, 12, func(c *Cpu) {
+		c.d.setWord(c.pop())
+	}},
+	0xD2: command{"JP NC, nn", 2, 12, func(c *Cpu) {
+		c.jpNF(flagC, BytesToWord(c.inst.p[1], c.inst.p[0]))
+	}},
+	0xD4: command{"CALL NC, nn", 2, 12, func(c *Cpu) {
+		c.callNF(flagC, BytesToWord(c.inst.p[1], c.inst.p[0]))
+	}},
+	0xD5: command{"PUSH DE", 0, 16, func(c *Cpu) {
+		c.push(c.d.Word())
+	}},
+	0xD6: command{"SUB #", 1, 8, func(c *Cpu) {
+		c.a.set(c.sub(c.a.Byte(), c.inst.p[0]))
+	}},
+	0xD7: command{"RST 10H", 0, 16, func(c *Cpu) {
+		c.rst(0x0010)
+	}},
+	0xD8: command{"RET C", 0, 8, func(c *Cpu) {
+		c.retF(flagC)
+	}},
+	0xD9: command{"RETI", 0, 16, func(c *Cpu) {
+		c.reti()
+	}},
+	0xDA: command{"JP C, nn", 2, 12, func(c *Cpu) {
+		c.jpF(flagC, BytesToWord(c.inst.p[1], c.inst.p[0]))
+	}},
+	0xDC: command{"CALL C, nn", 2, 12, func(c *Cpu) {
+		c.callF(flagC, BytesToWord(c.inst.p[1], c.inst.p[0]))
+	}},
+	0xDE: command{"SBC A, #", 1, 8, func(c *Cpu) {
+		c.a.set(c.sbc(c.a.Byte(), c.inst.p[0]))
+	}},
+	0xDF: command{"RST 18H", 0, 16, func(c *Cpu) {
+		c.rst(0x0018)
+	}},
 	0xE0: command{"LDH (n), A", 1, 12, func(c *Cpu) {
 		c.writeByte(Word(0xFF00+uint16(c.inst.p[0])), c.a.Byte())
 	}},
+	0xE1: command{"POP HL", 0, 12, func(c *Cpu) {
+		c.h.setWord(c.pop())
+	}},
 	0xE2: command{"LD (C), A", 0, 8, func(c *Cpu) {
 		c.writeByte(Word(0xFF00+uint16(c.c.Byte())), c.a.Byte())
 	}},
+	0xE5: command{"PUSH HL", 0, 16, func(c *Cpu) {
+		c.push(c.h.Word())
+	}},
 	0xE6: command{"AND #", 1, 8, func(c *Cpu) {
-		c.a.set(c.and(c.inst.p[0], c.a.Byte()))
+		c.a.set(c.and(c.a.Byte(), c.inst.p[0]))
+	}},
+	0xE7: command{"RST 20H", 0, 16, func(c *Cpu) {
+		c.rst(0x0020)
+	}},
+	0xE8: command{"ADD SP, n", 1, 16, func(c *Cpu) {
+		c.sp = register16(c.addWordR(c.sp, c.inst.p[0]))
+	}},
+	0xE9: command{"JP (HL)", 0, 4, func(c *Cpu) {
+		c.jp(c.h.Word())
 	}},
 	0xEA: command{"LD (nn), A", 2, 16, func(c *Cpu) {
-		// panic("untested") XXX
 		c.writeByte(BytesToWord(c.inst.p[1], c.inst.p[0]), c.a.Byte())
 	}},
+	0xEE: command{"XOR #", 1, 8, func(c *Cpu) {
+		c.a.set(c.xor(c.a.Byte(), c.inst.p[0]))
+	}},
+	0xEF: command{"RST 28H", 0, 16, func(c *Cpu) {
+		c.rst(0x0028)
+	}},
 	0xF0: command{"LDH A, (n)", 1, 12, func(c *Cpu) {
 		c.a.set(c.readByte(Word(0xFF00 + uint16(c.inst.p[0]))))
 	}},
+	0xF1: command{"POP AF", 0, 12, func(c *Cpu) {
+		c.a.setWord(c.pop())
+	}},
 	0xF2: command{"LD A, (C)", 0, 8, func(c *Cpu) {
-		// panic("untested") XXX
 		c.a.set(c.readByte(Word(0xFF00 + uint16(c.c.Byte()))))
 	}},
 	0xF3: command{"DI", 0, 4, func(c *Cpu) {
-		c.imeDisableNext = 2 // disable interrupts after this and the next instruction
+		c.di()
+	}},
+	0xF5: command{"PUSH AF", 0, 16, func(c *Cpu) {
+		c.push(c.a.Word())
+	}},
+	0xF6: command{"OR #", 1, 8, func(c *Cpu) {
+		c.a.set(c.or(c.a.Byte(), c.inst.p[0]))
+	}},
+	0xF7: command{"RST 30H", 0, 16, func(c *Cpu) {
+		c.rst(0x0030)
 	}},
 	0xF8: command{"LDHL SP, n", 1, 12, func(c *Cpu) {
-		panic("untested")
 		c.h.setWord(c.addWordR(c.sp, c.inst.p[0]))
 	}},
+	0xF9: command{"LD SP, HL", 0, 8, func(c *Cpu) {
+		c.sp = register16(c.h.Word())
+	}},
 	0xFA: command{"LD A, (nn)", 2, 16, func(c *Cpu) {
-		panic("untested")
-		nn := BytesToWord(c.inst.p[1], c.inst.p[0])
-		c.a.set(c.readByte(nn))
+		c.a.set(c.readByte(BytesToWord(c.inst.p[1], c.inst.p[0])))
+	}},
+	0xFB: command{"EI", 0, 4, func(c *Cpu) {
+		c.ei()
 	}},
 	0xFE: command{"CP #", 1, 8, func(c *Cpu) {
 		c.sub(c.a.Byte(), c.inst.p[0])
 	}},
+	0xFF: command{"RST 38H", 0, 16, func(c *Cpu) {
+		c.rst(0x0038)
+	}},
+}
+
+// cbCommandTable holds the 0xCB prefixed opcodes, indexed by the second byte
+// 0x40*group + 8*b + r(B, C, D, E, H, L, (HL), A).
+var cbCommandTable = [256]command{
+	0x00: command{"RLC B", 0, 8, func(c *Cpu) {
+		c.b.set(c.rlc(c.b.Byte()))
+	}},
+	0x01: command{"RLC C", 0, 8, func(c *Cpu) {
+		c.c.set(c.rlc(c.c.Byte()))
+	}},
+	0x02: command{"RLC D", 0, 8, func(c *Cpu) {
+		c.d.set(c.rlc(c.d.Byte()))
+	}},
+	0x03: command{"RLC E", 0, 8, func(c *Cpu) {
+		c.e.set(c.rlc(c.e.Byte()))
+	}},
+	0x04: command{"RLC H", 0, 8, func(c *Cpu) {
+		c.h.set(c.rlc(c.h.Byte()))
+	}},
+	0x05: command{"RLC L", 0, 8, func(c *Cpu) {
+		c.l.set(c.rlc(c.l.Byte()))
+	}},
+	0x06: command{"RLC (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.rlc(v))
+	}},
+	0x07: command{"RLC A", 0, 8, func(c *Cpu) {
+		c.a.set(c.rlc(c.a.Byte()))
+	}},
+	0x08: command{"RRC B", 0, 8, func(c *Cpu) {
+		c.b.set(c.rrc(c.b.Byte()))
+	}},
+	0x09: command{"RRC C", 0, 8, func(c *Cpu) {
+		c.c.set(c.rrc(c.c.Byte()))
+	}},
+	0x0A: command{"RRC D", 0, 8, func(c *Cpu) {
+		c.d.set(c.rrc(c.d.Byte()))
+	}},
+	0x0B: command{"RRC E", 0, 8, func(c *Cpu) {
+		c.e.set(c.rrc(c.e.Byte()))
+	}},
+	0x0C: command{"RRC H", 0, 8, func(c *Cpu) {
+		c.h.set(c.rrc(c.h.Byte()))
+	}},
+	0x0D: command{"RRC L", 0, 8, func(c *Cpu) {
+		c.l.set(c.rrc(c.l.Byte()))
+	}},
+	0x0E: command{"RRC (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.rrc(v))
+	}},
+	0x0F: command{"RRC A", 0, 8, func(c *Cpu) {
+		c.a.set(c.rrc(c.a.Byte()))
+	}},
+	0x10: command{"RL B", 0, 8, func(c *Cpu) {
+		c.b.set(c.rl(c.b.Byte()))
+	}},
+	0x11: command{"RL C", 0, 8, func(c *Cpu) {
+		c.c.set(c.rl(c.c.Byte()))
+	}},
+	0x12: command{"RL D", 0, 8, func(c *Cpu) {
+		c.d.set(c.rl(c.d.Byte()))
+	}},
+	0x13: command{"RL E", 0, 8, func(c *Cpu) {
+		c.e.set(c.rl(c.e.Byte()))
+	}},
+	0x14: command{"RL H", 0, 8, func(c *Cpu) {
+		c.h.set(c.rl(c.h.Byte()))
+	}},
+	0x15: command{"RL L", 0, 8, func(c *Cpu) {
+		c.l.set(c.rl(c.l.Byte()))
+	}},
+	0x16: command{"RL (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.rl(v))
+	}},
+	0x17: command{"RL A", 0, 8, func(c *Cpu) {
+		c.a.set(c.rl(c.a.Byte()))
+	}},
+	0x18: command{"RR B", 0, 8, func(c *Cpu) {
+		c.b.set(c.rr(c.b.Byte()))
+	}},
+	0x19: command{"RR C", 0, 8, func(c *Cpu) {
+		c.c.set(c.rr(c.c.Byte()))
+	}},
+	0x1A: command{"RR D", 0, 8, func(c *Cpu) {
+		c.d.set(c.rr(c.d.Byte()))
+	}},
+	0x1B: command{"RR E", 0, 8, func(c *Cpu) {
+		c.e.set(c.rr(c.e.Byte()))
+	}},
+	0x1C: command{"RR H", 0, 8, func(c *Cpu) {
+		c.h.set(c.rr(c.h.Byte()))
+	}},
+	0x1D: command{"RR L", 0, 8, func(c *Cpu) {
+		c.l.set(c.rr(c.l.Byte()))
+	}},
+	0x1E: command{"RR (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.rr(v))
+	}},
+	0x1F: command{"RR A", 0, 8, func(c *Cpu) {
+		c.a.set(c.rr(c.a.Byte()))
+	}},
+	0x20: command{"SLA B", 0, 8, func(c *Cpu) {
+		c.b.set(c.sla(c.b.Byte()))
+	}},
+	0x21: command{"SLA C", 0, 8, func(c *Cpu) {
+		c.c.set(c.sla(c.c.Byte()))
+	}},
+	0x22: command{"SLA D", 0, 8, func(c *Cpu) {
+		c.d.set(c.sla(c.d.Byte()))
+	}},
+	0x23: command{"SLA E", 0, 8, func(c *Cpu) {
+		c.e.set(c.sla(c.e.Byte()))
+	}},
+	0x24: command{"SLA H", 0, 8, func(c *Cpu) {
+		c.h.set(c.sla(c.h.Byte()))
+	}},
+	0x25: command{"SLA L", 0, 8, func(c *Cpu) {
+		c.l.set(c.sla(c.l.Byte()))
+	}},
+	0x26: command{"SLA (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.sla(v))
+	}},
+	0x27: command{"SLA A", 0, 8, func(c *Cpu) {
+		c.a.set(c.sla(c.a.Byte()))
+	}},
+	0x28: command{"SRA B", 0, 8, func(c *Cpu) {
+		c.b.set(c.sra(c.b.Byte()))
+	}},
+	0x29: command{"SRA C", 0, 8, func(c *Cpu) {
+		c.c.set(c.sra(c.c.Byte()))
+	}},
+	0x2A: command{"SRA D", 0, 8, func(c *Cpu) {
+		c.d.set(c.sra(c.d.Byte()))
+	}},
+	0x2B: command{"SRA E", 0, 8, func(c *Cpu) {
+		c.e.set(c.sra(c.e.Byte()))
+	}},
+	0x2C: command{"SRA H", 0, 8, func(c *Cpu) {
+		c.h.set(c.sra(c.h.Byte()))
+	}},
+	0x2D: command{"SRA L", 0, 8, func(c *Cpu) {
+		c.l.set(c.sra(c.l.Byte()))
+	}},
+	0x2E: command{"SRA (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.sra(v))
+	}},
+	0x2F: command{"SRA A", 0, 8, func(c *Cpu) {
+		c.a.set(c.sra(c.a.Byte()))
+	}},
+	0x30: command{"SWAP B", 0, 8, func(c *Cpu) {
+		c.b.set(c.swap(c.b.Byte()))
+	}},
+	0x31: command{"SWAP C", 0, 8, func(c *Cpu) {
+		c.c.set(c.swap(c.c.Byte()))
+	}},
+	0x32: command{"SWAP D", 0, 8, func(c *Cpu) {
+		c.d.set(c.swap(c.d.Byte()))
+	}},
+	0x33: command{"SWAP E", 0, 8, func(c *Cpu) {
+		c.e.set(c.swap(c.e.Byte()))
+	}},
+	0x34: command{"SWAP H", 0, 8, func(c *Cpu) {
+		c.h.set(c.swap(c.h.Byte()))
+	}},
+	0x35: command{"SWAP L", 0, 8, func(c *Cpu) {
+		c.l.set(c.swap(c.l.Byte()))
+	}},
+	0x36: command{"SWAP (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.swap(v))
+	}},
+	0x37: command{"SWAP A", 0, 8, func(c *Cpu) {
+		c.a.set(c.swap(c.a.Byte()))
+	}},
+	0x38: command{"SRL B", 0, 8, func(c *Cpu) {
+		c.b.set(c.srl(c.b.Byte()))
+	}},
+	0x39: command{"SRL C", 0, 8, func(c *Cpu) {
+		c.c.set(c.srl(c.c.Byte()))
+	}},
+	0x3A: command{"SRL D", 0, 8, func(c *Cpu) {
+		c.d.set(c.srl(c.d.Byte()))
+	}},
+	0x3B: command{"SRL E", 0, 8, func(c *Cpu) {
+		c.e.set(c.srl(c.e.Byte()))
+	}},
+	0x3C: command{"SRL H", 0, 8, func(c *Cpu) {
+		c.h.set(c.srl(c.h.Byte()))
+	}},
+	0x3D: command{"SRL L", 0, 8, func(c *Cpu) {
+		c.l.set(c.srl(c.l.Byte()))
+	}},
+	0x3E: command{"SRL (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.srl(v))
+	}},
+	0x3F: command{"SRL A", 0, 8, func(c *Cpu) {
+		c.a.set(c.srl(c.a.Byte()))
+	}},
+	0x40: command{"BIT 0, B", 0, 8, func(c *Cpu) {
+		c.bit(0, c.b.Byte())
+	}},
+	0x41: command{"BIT 0, C", 0, 8, func(c *Cpu) {
+		c.bit(0, c.c.Byte())
+	}},
+	0x42: command{"BIT 0, D", 0, 8, func(c *Cpu) {
+		c.bit(0, c.d.Byte())
+	}},
+	0x43: command{"BIT 0, E", 0, 8, func(c *Cpu) {
+		c.bit(0, c.e.Byte())
+	}},
+	0x44: command{"BIT 0, H", 0, 8, func(c *Cpu) {
+		c.bit(0, c.h.Byte())
+	}},
+	0x45: command{"BIT 0, L", 0, 8, func(c *Cpu) {
+		c.bit(0, c.l.Byte())
+	}},
+	0x46: command{"BIT 0, (HL)", 0, 12, func(c *Cpu) {
+		c.bit(0, c.readByte(c.h.Word()))
+	}},
+	0x47: command{"BIT 0, A", 0, 8, func(c *Cpu) {
+		c.bit(0, c.a.Byte())
+	}},
+	0x48: command{"BIT 1, B", 0, 8, func(c *Cpu) {
+		c.bit(1, c.b.Byte())
+	}},
+	0x49: command{"BIT 1, C", 0, 8, func(c *Cpu) {
+		c.bit(1, c.c.Byte())
+	}},
+	0x4A: command{"BIT 1, D", 0, 8, func(c *Cpu) {
+		c.bit(1, c.d.Byte())
+	}},
+	0x4B: command{"BIT 1, E", 0, 8, func(c *Cpu) {
+		c.bit(1, c.e.Byte())
+	}},
+	0x4C: command{"BIT 1, H", 0, 8, func(c *Cpu) {
+		c.bit(1, c.h.Byte())
+	}},
+	0x4D: command{"BIT 1, L", 0, 8, func(c *Cpu) {
+		c.bit(1, c.l.Byte())
+	}},
+	0x4E: command{"BIT 1, (HL)", 0, 12, func(c *Cpu) {
+		c.bit(1, c.readByte(c.h.Word()))
+	}},
+	0x4F: command{"BIT 1, A", 0, 8, func(c *Cpu) {
+		c.bit(1, c.a.Byte())
+	}},
+	0x50: command{"BIT 2, B", 0, 8, func(c *Cpu) {
+		c.bit(2, c.b.Byte())
+	}},
+	0x51: command{"BIT 2, C", 0, 8, func(c *Cpu) {
+		c.bit(2, c.c.Byte())
+	}},
+	0x52: command{"BIT 2, D", 0, 8, func(c *Cpu) {
+		c.bit(2, c.d.Byte())
+	}},
+	0x53: command{"BIT 2, E", 0, 8, func(c *Cpu) {
+		c.bit(2, c.e.Byte())
+	}},
+	0x54: command{"BIT 2, H", 0, 8, func(c *Cpu) {
+		c.bit(2, c.h.Byte())
+	}},
+	0x55: command{"BIT 2, L", 0, 8, func(c *Cpu) {
+		c.bit(2, c.l.Byte())
+	}},
+	0x56: command{"BIT 2, (HL)", 0, 12, func(c *Cpu) {
+		c.bit(2, c.readByte(c.h.Word()))
+	}},
+	0x57: command{"BIT 2, A", 0, 8, func(c *Cpu) {
+		c.bit(2, c.a.Byte())
+	}},
+	0x58: command{"BIT 3, B", 0, 8, func(c *Cpu) {
+		c.bit(3, c.b.Byte())
+	}},
+	0x59: command{"BIT 3, C", 0, 8, func(c *Cpu) {
+		c.bit(3, c.c.Byte())
+	}},
+	0x5A: command{"BIT 3, D", 0, 8, func(c *Cpu) {
+		c.bit(3, c.d.Byte())
+	}},
+	0x5B: command{"BIT 3, E", 0, 8, func(c *Cpu) {
+		c.bit(3, c.e.Byte())
+	}},
+	0x5C: command{"BIT 3, H", 0, 8, func(c *Cpu) {
+		c.bit(3, c.h.Byte())
+	}},
+	0x5D: command{"BIT 3, L", 0, 8, func(c *Cpu) {
+		c.bit(3, c.l.Byte())
+	}},
+	0x5E: command{"BIT 3, (HL)", 0, 12, func(c *Cpu) {
+		c.bit(3, c.readByte(c.h.Word()))
+	}},
+	0x5F: command{"BIT 3, A", 0, 8, func(c *Cpu) {
+		c.bit(3, c.a.Byte())
+	}},
+	0x60: command{"BIT 4, B", 0, 8, func(c *Cpu) {
+		c.bit(4, c.b.Byte())
+	}},
+	0x61: command{"BIT 4, C", 0, 8, func(c *Cpu) {
+		c.bit(4, c.c.Byte())
+	}},
+	0x62: command{"BIT 4, D", 0, 8, func(c *Cpu) {
+		c.bit(4, c.d.Byte())
+	}},
+	0x63: command{"BIT 4, E", 0, 8, func(c *Cpu) {
+		c.bit(4, c.e.Byte())
+	}},
+	0x64: command{"BIT 4, H", 0, 8, func(c *Cpu) {
+		c.bit(4, c.h.Byte())
+	}},
+	0x65: command{"BIT 4, L", 0, 8, func(c *Cpu) {
+		c.bit(4, c.l.Byte())
+	}},
+	0x66: command{"BIT 4, (HL)", 0, 12, func(c *Cpu) {
+		c.bit(4, c.readByte(c.h.Word()))
+	}},
+	0x67: command{"BIT 4, A", 0, 8, func(c *Cpu) {
+		c.bit(4, c.a.Byte())
+	}},
+	0x68: command{"BIT 5, B", 0, 8, func(c *Cpu) {
+		c.bit(5, c.b.Byte())
+	}},
+	0x69: command{"BIT 5, C", 0, 8, func(c *Cpu) {
+		c.bit(5, c.c.Byte())
+	}},
+	0x6A: command{"BIT 5, D", 0, 8, func(c *Cpu) {
+		c.bit(5, c.d.Byte())
+	}},
+	0x6B: command{"BIT 5, E", 0, 8, func(c *Cpu) {
+		c.bit(5, c.e.Byte())
+	}},
+	0x6C: command{"BIT 5, H", 0, 8, func(c *Cpu) {
+		c.bit(5, c.h.Byte())
+	}},
+	0x6D: command{"BIT 5, L", 0, 8, func(c *Cpu) {
+		c.bit(5, c.l.Byte())
+	}},
+	0x6E: command{"BIT 5, (HL)", 0, 12, func(c *Cpu) {
+		c.bit(5, c.readByte(c.h.Word()))
+	}},
+	0x6F: command{"BIT 5, A", 0, 8, func(c *Cpu) {
+		c.bit(5, c.a.Byte())
+	}},
+	0x70: command{"BIT 6, B", 0, 8, func(c *Cpu) {
+		c.bit(6, c.b.Byte())
+	}},
+	0x71: command{"BIT 6, C", 0, 8, func(c *Cpu) {
+		c.bit(6, c.c.Byte())
+	}},
+	0x72: command{"BIT 6, D", 0, 8, func(c *Cpu) {
+		c.bit(6, c.d.Byte())
+	}},
+	0x73: command{"BIT 6, E", 0, 8, func(c *Cpu) {
+		c.bit(6, c.e.Byte())
+	}},
+	0x74: command{"BIT 6, H", 0, 8, func(c *Cpu) {
+		c.bit(6, c.h.Byte())
+	}},
+	0x75: command{"BIT 6, L", 0, 8, func(c *Cpu) {
+		c.bit(6, c.l.Byte())
+	}},
+	0x76: command{"BIT 6, (HL)", 0, 12, func(c *Cpu) {
+		c.bit(6, c.readByte(c.h.Word()))
+	}},
+	0x77: command{"BIT 6, A", 0, 8, func(c *Cpu) {
+		c.bit(6, c.a.Byte())
+	}},
+	0x78: command{"BIT 7, B", 0, 8, func(c *Cpu) {
+		c.bit(7, c.b.Byte())
+	}},
+	0x79: command{"BIT 7, C", 0, 8, func(c *Cpu) {
+		c.bit(7, c.c.Byte())
+	}},
+	0x7A: command{"BIT 7, D", 0, 8, func(c *Cpu) {
+		c.bit(7, c.d.Byte())
+	}},
+	0x7B: command{"BIT 7, E", 0, 8, func(c *Cpu) {
+		c.bit(7, c.e.Byte())
+	}},
+	0x7C: command{"BIT 7, H", 0, 8, func(c *Cpu) {
+		c.bit(7, c.h.Byte())
+	}},
+	0x7D: command{"BIT 7, L", 0, 8, func(c *Cpu) {
+		c.bit(7, c.l.Byte())
+	}},
+	0x7E: command{"BIT 7, (HL)", 0, 12, func(c *Cpu) {
+		c.bit(7, c.readByte(c.h.Word()))
+	}},
+	0x7F: command{"BIT 7, A", 0, 8, func(c *Cpu) {
+		c.bit(7, c.a.Byte())
+	}},
+	0x80: command{"RES 0, B", 0, 8, func(c *Cpu) {
+		c.b.set(c.res(0, c.b.Byte()))
+	}},
+	0x81: command{"RES 0, C", 0, 8, func(c *Cpu) {
+		c.c.set(c.res(0, c.c.Byte()))
+	}},
+	0x82: command{"RES 0, D", 0, 8, func(c *Cpu) {
+		c.d.set(c.res(0, c.d.Byte()))
+	}},
+	0x83: command{"RES 0, E", 0, 8, func(c *Cpu) {
+		c.e.set(c.res(0, c.e.Byte()))
+	}},
+	0x84: command{"RES 0, H", 0, 8, func(c *Cpu) {
+		c.h.set(c.res(0, c.h.Byte()))
+	}},
+	0x85: command{"RES 0, L", 0, 8, func(c *Cpu) {
+		c.l.set(c.res(0, c.l.Byte()))
+	}},
+	0x86: command{"RES 0, (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.res(0, v))
+	}},
+	0x87: command{"RES 0, A", 0, 8, func(c *Cpu) {
+		c.a.set(c.res(0, c.a.Byte()))
+	}},
+	0x88: command{"RES 1, B", 0, 8, func(c *Cpu) {
+		c.b.set(c.res(1, c.b.Byte()))
+	}},
+	0x89: command{"RES 1, C", 0, 8, func(c *Cpu) {
+		c.c.set(c.res(1, c.c.Byte()))
+	}},
+	0x8A: command{"RES 1, D", 0, 8, func(c *Cpu) {
+		c.d.set(c.res(1, c.d.Byte()))
+	}},
+	0x8B: command{"RES 1, E", 0, 8, func(c *Cpu) {
+		c.e.set(c.res(1, c.e.Byte()))
+	}},
+	0x8C: command{"RES 1, H", 0, 8, func(c *Cpu) {
+		c.h.set(c.res(1, c.h.Byte()))
+	}},
+	0x8D: command{"RES 1, L", 0, 8, func(c *Cpu) {
+		c.l.set(c.res(1, c.l.Byte()))
+	}},
+	0x8E: command{"RES 1, (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.res(1, v))
+	}},
+	0x8F: command{"RES 1, A", 0, 8, func(c *Cpu) {
+		c.a.set(c.res(1, c.a.Byte()))
+	}},
+	0x90: command{"RES 2, B", 0, 8, func(c *Cpu) {
+		c.b.set(c.res(2, c.b.Byte()))
+	}},
+	0x91: command{"RES 2, C", 0, 8, func(c *Cpu) {
+		c.c.set(c.res(2, c.c.Byte()))
+	}},
+	0x92: command{"RES 2, D", 0, 8, func(c *Cpu) {
+		c.d.set(c.res(2, c.d.Byte()))
+	}},
+	0x93: command{"RES 2, E", 0, 8, func(c *Cpu) {
+		c.e.set(c.res(2, c.e.Byte()))
+	}},
+	0x94: command{"RES 2, H", 0, 8, func(c *Cpu) {
+		c.h.set(c.res(2, c.h.Byte()))
+	}},
+	0x95: command{"RES 2, L", 0, 8, func(c *Cpu) {
+		c.l.set(c.res(2, c.l.Byte()))
+	}},
+	0x96: command{"RES 2, (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.res(2, v))
+	}},
+	0x97: command{"RES 2, A", 0, 8, func(c *Cpu) {
+		c.a.set(c.res(2, c.a.Byte()))
+	}},
+	0x98: command{"RES 3, B", 0, 8, func(c *Cpu) {
+		c.b.set(c.res(3, c.b.Byte()))
+	}},
+	0x99: command{"RES 3, C", 0, 8, func(c *Cpu) {
+		c.c.set(c.res(3, c.c.Byte()))
+	}},
+	0x9A: command{"RES 3, D", 0, 8, func(c *Cpu) {
+		c.d.set(c.res(3, c.d.Byte()))
+	}},
+	0x9B: command{"RES 3, E", 0, 8, func(c *Cpu) {
+		c.e.set(c.res(3, c.e.Byte()))
+	}},
+	0x9C: command{"RES 3, H", 0, 8, func(c *Cpu) {
+		c.h.set(c.res(3, c.h.Byte()))
+	}},
+	0x9D: command{"RES 3, L", 0, 8, func(c *Cpu) {
+		c.l.set(c.res(3, c.l.Byte()))
+	}},
+	0x9E: command{"RES 3, (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.res(3, v))
+	}},
+	0x9F: command{"RES 3, A", 0, 8, func(c *Cpu) {
+		c.a.set(c.res(3, c.a.Byte()))
+	}},
+	0xA0: command{"RES 4, B", 0, 8, func(c *Cpu) {
+		c.b.set(c.res(4, c.b.Byte()))
+	}},
+	0xA1: command{"RES 4, C", 0, 8, func(c *Cpu) {
+		c.c.set(c.res(4, c.c.Byte()))
+	}},
+	0xA2: command{"RES 4, D", 0, 8, func(c *Cpu) {
+		c.d.set(c.res(4, c.d.Byte()))
+	}},
+	0xA3: command{"RES 4, E", 0, 8, func(c *Cpu) {
+		c.e.set(c.res(4, c.e.Byte()))
+	}},
+	0xA4: command{"RES 4, H", 0, 8, func(c *Cpu) {
+		c.h.set(c.res(4, c.h.Byte()))
+	}},
+	0xA5: command{"RES 4, L", 0, 8, func(c *Cpu) {
+		c.l.set(c.res(4, c.l.Byte()))
+	}},
+	0xA6: command{"RES 4, (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.res(4, v))
+	}},
+	0xA7: command{"RES 4, A", 0, 8, func(c *Cpu) {
+		c.a.set(c.res(4, c.a.Byte()))
+	}},
+	0xA8: command{"RES 5, B", 0, 8, func(c *Cpu) {
+		c.b.set(c.res(5, c.b.Byte()))
+	}},
+	0xA9: command{"RES 5, C", 0, 8, func(c *Cpu) {
+		c.c.set(c.res(5, c.c.Byte()))
+	}},
+	0xAA: command{"RES 5, D", 0, 8, func(c *Cpu) {
+		c.d.set(c.res(5, c.d.Byte()))
+	}},
+	0xAB: command{"RES 5, E", 0, 8, func(c *Cpu) {
+		c.e.set(c.res(5, c.e.Byte()))
+	}},
+	0xAC: command{"RES 5, H", 0, 8, func(c *Cpu) {
+		c.h.set(c.res(5, c.h.Byte()))
+	}},
+	0xAD: command{"RES 5, L", 0, 8, func(c *Cpu) {
+		c.l.set(c.res(5, c.l.Byte()))
+	}},
+	0xAE: command{"RES 5, (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.res(5, v))
+	}},
+	0xAF: command{"RES 5, A", 0, 8, func(c *Cpu) {
+		c.a.set(c.res(5, c.a.Byte()))
+	}},
+	0xB0: command{"RES 6, B", 0, 8, func(c *Cpu) {
+		c.b.set(c.res(6, c.b.Byte()))
+	}},
+	0xB1: command{"RES 6, C", 0, 8, func(c *Cpu) {
+		c.c.set(c.res(6, c.c.Byte()))
+	}},
+	0xB2: command{"RES 6, D", 0, 8, func(c *Cpu) {
+		c.d.set(c.res(6, c.d.Byte()))
+	}},
+	0xB3: command{"RES 6, E", 0, 8, func(c *Cpu) {
+		c.e.set(c.res(6, c.e.Byte()))
+	}},
+	0xB4: command{"RES 6, H", 0, 8, func(c *Cpu) {
+		c.h.set(c.res(6, c.h.Byte()))
+	}},
+	0xB5: command{"RES 6, L", 0, 8, func(c *Cpu) {
+		c.l.set(c.res(6, c.l.Byte()))
+	}},
+	0xB6: command{"RES 6, (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.res(6, v))
+	}},
+	0xB7: command{"RES 6, A", 0, 8, func(c *Cpu) {
+		c.a.set(c.res(6, c.a.Byte()))
+	}},
+	0xB8: command{"RES 7, B", 0, 8, func(c *Cpu) {
+		c.b.set(c.res(7, c.b.Byte()))
+	}},
+	0xB9: command{"RES 7, C", 0, 8, func(c *Cpu) {
+		c.c.set(c.res(7, c.c.Byte()))
+	}},
+	0xBA: command{"RES 7, D", 0, 8, func(c *Cpu) {
+		c.d.set(c.res(7, c.d.Byte()))
+	}},
+	0xBB: command{"RES 7, E", 0, 8, func(c *Cpu) {
+		c.e.set(c.res(7, c.e.Byte()))
+	}},
+	0xBC: command{"RES 7, H", 0, 8, func(c *Cpu) {
+		c.h.set(c.res(7, c.h.Byte()))
+	}},
+	0xBD: command{"RES 7, L", 0, 8, func(c *Cpu) {
+		c.l.set(c.res(7, c.l.Byte()))
+	}},
+	0xBE: command{"RES 7, (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.res(7, v))
+	}},
+	0xBF: command{"RES 7, A", 0, 8, func(c *Cpu) {
+		c.a.set(c.res(7, c.a.Byte()))
+	}},
+	0xC0: command{"SET 0, B", 0, 8, func(c *Cpu) {
+		c.b.set(c.set(0, c.b.Byte()))
+	}},
+	0xC1: command{"SET 0, C", 0, 8, func(c *Cpu) {
+		c.c.set(c.set(0, c.c.Byte()))
+	}},
+	0xC2: command{"SET 0, D", 0, 8, func(c *Cpu) {
+		c.d.set(c.set(0, c.d.Byte()))
+	}},
+	0xC3: command{"SET 0, E", 0, 8, func(c *Cpu) {
+		c.e.set(c.set(0, c.e.Byte()))
+	}},
+	0xC4: command{"SET 0, H", 0, 8, func(c *Cpu) {
+		c.h.set(c.set(0, c.h.Byte()))
+	}},
+	0xC5: command{"SET 0, L", 0, 8, func(c *Cpu) {
+		c.l.set(c.set(0, c.l.Byte()))
+	}},
+	0xC6: command{"SET 0, (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.set(0, v))
+	}},
+	0xC7: command{"SET 0, A", 0, 8, func(c *Cpu) {
+		c.a.set(c.set(0, c.a.Byte()))
+	}},
+	0xC8: command{"SET 1, B", 0, 8, func(c *Cpu) {
+		c.b.set(c.set(1, c.b.Byte()))
+	}},
+	0xC9: command{"SET 1, C", 0, 8, func(c *Cpu) {
+		c.c.set(c.set(1, c.c.Byte()))
+	}},
+	0xCA: command{"SET 1, D", 0, 8, func(c *Cpu) {
+		c.d.set(c.set(1, c.d.Byte()))
+	}},
+	0xCB: command{"SET 1, E", 0, 8, func(c *Cpu) {
+		c.e.set(c.set(1, c.e.Byte()))
+	}},
+	0xCC: command{"SET 1, H", 0, 8, func(c *Cpu) {
+		c.h.set(c.set(1, c.h.Byte()))
+	}},
+	0xCD: command{"SET 1, L", 0, 8, func(c *Cpu) {
+		c.l.set(c.set(1, c.l.Byte()))
+	}},
+	0xCE: command{"SET 1, (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.set(1, v))
+	}},
+	0xCF: command{"SET 1, A", 0, 8, func(c *Cpu) {
+		c.a.set(c.set(1, c.a.Byte()))
+	}},
+	0xD0: command{"SET 2, B", 0, 8, func(c *Cpu) {
+		c.b.set(c.set(2, c.b.Byte()))
+	}},
+	0xD1: command{"SET 2, C", 0, 8, func(c *Cpu) {
+		c.c.set(c.set(2, c.c.Byte()))
+	}},
+	0xD2: command{"SET 2, D", 0, 8, func(c *Cpu) {
+		c.d.set(c.set(2, c.d.Byte()))
+	}},
+	0xD3: command{"SET 2, E", 0, 8, func(c *Cpu) {
+		c.e.set(c.set(2, c.e.Byte()))
+	}},
+	0xD4: command{"SET 2, H", 0, 8, func(c *Cpu) {
+		c.h.set(c.set(2, c.h.Byte()))
+	}},
+	0xD5: command{"SET 2, L", 0, 8, func(c *Cpu) {
+		c.l.set(c.set(2, c.l.Byte()))
+	}},
+	0xD6: command{"SET 2, (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.set(2, v))
+	}},
+	0xD7: command{"SET 2, A", 0, 8, func(c *Cpu) {
+		c.a.set(c.set(2, c.a.Byte()))
+	}},
+	0xD8: command{"SET 3, B", 0, 8, func(c *Cpu) {
+		c.b.set(c.set(3, c.b.Byte()))
+	}},
+	0xD9: command{"SET 3, C", 0, 8, func(c *Cpu) {
+		c.c.set(c.set(3, c.c.Byte()))
+	}},
+	0xDA: command{"SET 3, D", 0, 8, func(c *Cpu) {
+		c.d.set(c.set(3, c.d.Byte()))
+	}},
+	0xDB: command{"SET 3, E", 0, 8, func(c *Cpu) {
+		c.e.set(c.set(3, c.e.Byte()))
+	}},
+	0xDC: command{"SET 3, H", 0, 8, func(c *Cpu) {
+		c.h.set(c.set(3, c.h.Byte()))
+	}},
+	0xDD: command{"SET 3, L", 0, 8, func(c *Cpu) {
+		c.l.set(c.set(3, c.l.Byte()))
+	}},
+	0xDE: command{"SET 3, (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.set(3, v))
+	}},
+	0xDF: command{"SET 3, A", 0, 8, func(c *Cpu) {
+		c.a.set(c.set(3, c.a.Byte()))
+	}},
+	0xE0: command{"SET 4, B", 0, 8, func(c *Cpu) {
+		c.b.set(c.set(4, c.b.Byte()))
+	}},
+	0xE1: command{"SET 4, C", 0, 8, func(c *Cpu) {
+		c.c.set(c.set(4, c.c.Byte()))
+	}},
+	0xE2: command{"SET 4, D", 0, 8, func(c *Cpu) {
+		c.d.set(c.set(4, c.d.Byte()))
+	}},
+	0xE3: command{"SET 4, E", 0, 8, func(c *Cpu) {
+		c.e.set(c.set(4, c.e.Byte()))
+	}},
+	0xE4: command{"SET 4, H", 0, 8, func(c *Cpu) {
+		c.h.set(c.set(4, c.h.Byte()))
+	}},
+	0xE5: command{"SET 4, L", 0, 8, func(c *Cpu) {
+		c.l.set(c.set(4, c.l.Byte()))
+	}},
+	0xE6: command{"SET 4, (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.set(4, v))
+	}},
+	0xE7: command{"SET 4, A", 0, 8, func(c *Cpu) {
+		c.a.set(c.set(4, c.a.Byte()))
+	}},
+	0xE8: command{"SET 5, B", 0, 8, func(c *Cpu) {
+		c.b.set(c.set(5, c.b.Byte()))
+	}},
+	0xE9: command{"SET 5, C", 0, 8, func(c *Cpu) {
+		c.c.set(c.set(5, c.c.Byte()))
+	}},
+	0xEA: command{"SET 5, D", 0, 8, func(c *Cpu) {
+		c.d.set(c.set(5, c.d.Byte()))
+	}},
+	0xEB: command{"SET 5, E", 0, 8, func(c *Cpu) {
+		c.e.set(c.set(5, c.e.Byte()))
+	}},
+	0xEC: command{"SET 5, H", 0, 8, func(c *Cpu) {
+		c.h.set(c.set(5, c.h.Byte()))
+	}},
+	0xED: command{"SET 5, L", 0, 8, func(c *Cpu) {
+		c.l.set(c.set(5, c.l.Byte()))
+	}},
+	0xEE: command{"SET 5, (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.set(5, v))
+	}},
+	0xEF: command{"SET 5, A", 0, 8, func(c *Cpu) {
+		c.a.set(c.set(5, c.a.Byte()))
+	}},
+	0xF0: command{"SET 6, B", 0, 8, func(c *Cpu) {
+		c.b.set(c.set(6, c.b.Byte()))
+	}},
+	0xF1: command{"SET 6, C", 0, 8, func(c *Cpu) {
+		c.c.set(c.set(6, c.c.Byte()))
+	}},
+	0xF2: command{"SET 6, D", 0, 8, func(c *Cpu) {
+		c.d.set(c.set(6, c.d.Byte()))
+	}},
+	0xF3: command{"SET 6, E", 0, 8, func(c *Cpu) {
+		c.e.set(c.set(6, c.e.Byte()))
+	}},
+	0xF4: command{"SET 6, H", 0, 8, func(c *Cpu) {
+		c.h.set(c.set(6, c.h.Byte()))
+	}},
+	0xF5: command{"SET 6, L", 0, 8, func(c *Cpu) {
+		c.l.set(c.set(6, c.l.Byte()))
+	}},
+	0xF6: command{"SET 6, (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.set(6, v))
+	}},
+	0xF7: command{"SET 6, A", 0, 8, func(c *Cpu) {
+		c.a.set(c.set(6, c.a.Byte()))
+	}},
+	0xF8: command{"SET 7, B", 0, 8, func(c *Cpu) {
+		c.b.set(c.set(7, c.b.Byte()))
+	}},
+	0xF9: command{"SET 7, C", 0, 8, func(c *Cpu) {
+		c.c.set(c.set(7, c.c.Byte()))
+	}},
+	0xFA: command{"SET 7, D", 0, 8, func(c *Cpu) {
+		c.d.set(c.set(7, c.d.Byte()))
+	}},
+	0xFB: command{"SET 7, E", 0, 8, func(c *Cpu) {
+		c.e.set(c.set(7, c.e.Byte()))
+	}},
+	0xFC: command{"SET 7, H", 0, 8, func(c *Cpu) {
+		c.h.set(c.set(7, c.h.Byte()))
+	}},
+	0xFD: command{"SET 7, L", 0, 8, func(c *Cpu) {
+		c.l.set(c.set(7, c.l.Byte()))
+	}},
+	0xFE: command{"SET 7, (HL)", 0, 16, func(c *Cpu) {
+		v := c.readByte(c.h.Word())
+		c.writeByte(c.h.Word(), c.set(7, v))
+	}},
+	0xFF: command{"SET 7, A", 0, 8, func(c *Cpu) {
+		c.a.set(c.set(7, c.a.Byte()))
+	}},
 }
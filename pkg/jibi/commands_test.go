@@ -5,6 +5,32 @@ import (
 	"testing"
 )
 
+func TestOp00(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x00})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// NOP -- only moves pc
+	cpu.a.set(0x12)
+	cpu.f.set(0xB0)
+	cpu.b.setWord(0x3456)
+	cpu.d.setWord(0x789A)
+	cpu.h.setWord(0xBCDE)
+	cpu.sp = 0xFFF0
+	before := cpu.registers()
+	cpu.step(false, 0)
+	if cpu.pc != Word(0x01) {
+		t.Error()
+	}
+	if cpu.t != 4 {
+		t.Error()
+	}
+	after := cpu.registers()
+	after.PC, after.Cycles = before.PC, before.Cycles
+	if after != before {
+		t.Error(before, after)
+	}
+}
+
 func TestOp01(t *testing.T) {
 	cpu := NewCpu(newTestMmu(), []Byte{0x01, 0x32, 0x01})
 	defer cpu.RunCommand(CmdStop, nil)
@@ -245,7 +271,7 @@ func TestOp07(t *testing.T) {
 		t.Error()
 	}
 
-	// RLCA -- bit7 high, Z, NC
+	// RLCA -- zero result, Z always reset, NC
 	cpu.pc = 0
 	cpu.a.set(Byte(0x00))
 	cpu.fetch()
@@ -253,7 +279,7 @@ func TestOp07(t *testing.T) {
 	if cpu.a.Byte() != Byte(0x00) {
 		t.Error(fmt.Sprintf("0x%02X", cpu.a.Byte()))
 	}
-	if cpu.f.getFlag(flagZ) != true {
+	if cpu.f.getFlag(flagZ) != false {
 		t.Error()
 	}
 	if cpu.f.getFlag(flagN) != false {
@@ -339,6 +365,69 @@ func TestOp0E(t *testing.T) {
 	}
 }
 
+func TestOp10(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x10, 0x00, 0x3C, 0x10, 0x00})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// STOP -- resets DIV, enters stop mode
+	cpu.timer.counter = 0x1234
+	cpu.step(false, 0)
+	if cpu.pc != Word(0x02) {
+		t.Error()
+	}
+	if cpu.timer.counter != Word(0x0000) {
+		t.Error()
+	}
+	if cpu.peekByte(AddrDIV) != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.stopped != true {
+		t.Error()
+	}
+
+	// STOP -- stays stopped without keypad input
+	cpu.writeByte(AddrIE, Byte(InterruptVblank))
+	cpu.writeByte(AddrIF, Byte(InterruptVblank))
+	cpu.step(false, 0)
+	if cpu.stopped != true {
+		t.Error()
+	}
+	if cpu.pc != Word(0x02) {
+		t.Error()
+	}
+	if cpu.peekByte(AddrDIV) != Byte(0x00) {
+		t.Error()
+	}
+
+	// STOP -- keypad input wakes and continues
+	cpu.writeByte(AddrIE, 0)
+	cpu.writeByte(AddrIF, Byte(InterruptKeypad))
+	cpu.step(false, 0)
+	if cpu.stopped != false {
+		t.Error()
+	}
+	if cpu.a.Byte() != Byte(0x01) {
+		t.Error()
+	}
+
+	// STOP -- cgb speed switch when armed
+	cpu.cgb = true
+	cpu.writeByte(AddrKEY1, 0x01)
+	if cpu.readByte(AddrKEY1) != Byte(0x7F) {
+		t.Error()
+	}
+	cpu.step(false, 0)
+	if cpu.stopped != false {
+		t.Error()
+	}
+	if cpu.doubleSpeed != true {
+		t.Error()
+	}
+	if cpu.readByte(AddrKEY1) != Byte(0xFE) {
+		t.Error()
+	}
+}
+
 func TestOp11(t *testing.T) {
 	cpu := NewCpu(newTestMmu(), []Byte{0x11, 0x32, 0x01})
 	defer cpu.RunCommand(CmdStop, nil)
@@ -442,7 +531,7 @@ func TestOp17(t *testing.T) {
 		t.Error()
 	}
 
-	// RLA -- no carry, bit7 high, C, Z
+	// RLA -- no carry, bit7 high, C, Z always reset
 	cpu.pc = 0
 	cpu.f.resetFlag(flagC)
 	cpu.a.set(Byte(0x80))
@@ -451,7 +540,7 @@ func TestOp17(t *testing.T) {
 	if cpu.a.Byte() != Byte(0x00) {
 		t.Error(fmt.Sprintf("0x%02X", cpu.c.Byte()))
 	}
-	if cpu.f.getFlag(flagZ) != true {
+	if cpu.f.getFlag(flagZ) != false {
 		t.Error()
 	}
 	if cpu.f.getFlag(flagN) != false {
@@ -464,7 +553,7 @@ func TestOp17(t *testing.T) {
 		t.Error()
 	}
 
-	// RLA -- no carry, bit7 low, NC, Z
+	// RLA -- no carry, bit7 low, NC, Z always reset
 	cpu.pc = 0
 	cpu.f.resetFlag(flagC)
 	cpu.a.set(Byte(0x00))
@@ -473,7 +562,7 @@ func TestOp17(t *testing.T) {
 	if cpu.a.Byte() != Byte(0x00) {
 		t.Error(fmt.Sprintf("0x%02X", cpu.c.Byte()))
 	}
-	if cpu.f.getFlag(flagZ) != true {
+	if cpu.f.getFlag(flagZ) != false {
 		t.Error()
 	}
 	if cpu.f.getFlag(flagN) != false {
@@ -743,6 +832,92 @@ func TestOp4F(t *testing.T) {
 	}
 }
 
+func TestOp76(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x76, 0x3C, 0x3C})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// HALT -- ime, nothing pending
+	cpu.sp = register16(0xFFFE)
+	cpu.ime = 1
+	cpu.writeByte(AddrIE, Byte(InterruptTimer))
+	cpu.step(false, 0)
+	if cpu.halted != true {
+		t.Error()
+	}
+	if cpu.pc != Word(0x01) {
+		t.Error()
+	}
+
+	// HALT -- disabled interrupt does not wake
+	cpu.writeByte(AddrIF, Byte(InterruptVblank))
+	cpu.step(false, 0)
+	if cpu.halted != true {
+		t.Error()
+	}
+	if cpu.pc != Word(0x01) {
+		t.Error()
+	}
+	if cpu.t != 4 {
+		t.Error()
+	}
+
+	// HALT -- enabled interrupt wakes and is serviced
+	cpu.writeByte(AddrIF, Byte(InterruptTimer))
+	cpu.step(false, 0)
+	if cpu.halted != false {
+		t.Error()
+	}
+	if cpu.pc != InterruptTimer.Address()+1 {
+		t.Error()
+	}
+	if cpu.readWord(0xFFFC) != Word(0x01) {
+		t.Error()
+	}
+
+	// HALT -- no ime, wakes without servicing
+	cpu.pc = 0
+	cpu.sp = register16(0xFFFE)
+	cpu.ime = 0
+	cpu.writeByte(AddrIF, 0)
+	cpu.step(false, 0)
+	if cpu.halted != true {
+		t.Error()
+	}
+	cpu.writeByte(AddrIF, Byte(InterruptTimer))
+	cpu.step(false, 0)
+	if cpu.halted != false {
+		t.Error()
+	}
+	if cpu.pc != Word(0x02) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFE) {
+		t.Error()
+	}
+
+	// HALT -- halt bug, no ime and interrupt pending
+	cpu.pc = 0
+	cpu.a.set(0x00)
+	cpu.step(false, 0)
+	if cpu.halted != false {
+		t.Error()
+	}
+	if cpu.pc != Word(0x01) {
+		t.Error()
+	}
+	cpu.step(false, 0)
+	if cpu.pc != Word(0x01) {
+		t.Error()
+	}
+	cpu.step(false, 0)
+	if cpu.pc != Word(0x02) {
+		t.Error()
+	}
+	if cpu.a.Byte() != Byte(0x02) {
+		t.Error()
+	}
+}
+
 func TestOp77(t *testing.T) {
 	cpu := NewCpu(newTestMmu(), []Byte{0x77})
 	defer cpu.RunCommand(CmdStop, nil)
@@ -1253,22 +1428,107 @@ func TestOpF0(t *testing.T) {
 }
 
 func TestOpF3(t *testing.T) {
-	cpu := NewCpu(newTestMmu(), []Byte{0xF3, 0xF0, 0x05})
+	cpu := NewCpu(newTestMmu(), []Byte{0xF3, 0xFB, 0xF3, 0x00, 0x00})
 	defer cpu.RunCommand(CmdStop, nil)
 
-	// DI
+	// DI -- takes effect immediately
+	cpu.ime = 1
 	cpu.fetch()
 	cpu.execute()
-	if cpu.ime != Bit(1) {
+	if cpu.ime != Bit(0) {
+		t.Error()
+	}
+
+	// DI -- cancels a pending EI
+	cpu.fetch()
+	cpu.execute()
+	cpu.fetch()
+	cpu.execute()
+	if cpu.ime != Bit(0) {
+		t.Error()
+	}
+	cpu.fetch()
+	cpu.execute()
+	if cpu.ime != Bit(0) {
 		t.Error()
 	}
+}
+
+func TestOpFB(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xFB, 0x00, 0x00, 0xFB, 0xFB, 0x00, 0xFB, 0x00, 0x00, 0xFB, 0x76, 0x00})
+	defer cpu.RunCommand(CmdStop, nil)
 
-	// next instruction
+	// EI -- delayed by one instruction
+	cpu.ime = 0
 	cpu.fetch()
 	cpu.execute()
 	if cpu.ime != Bit(0) {
 		t.Error()
 	}
+	cpu.fetch()
+	cpu.execute()
+	if cpu.ime != Bit(1) {
+		t.Error()
+	}
+	cpu.fetch()
+	cpu.execute()
+	if cpu.ime != Bit(1) {
+		t.Error()
+	}
+
+	// EI -- a second EI does not extend the delay
+	cpu.ime = 0
+	cpu.fetch()
+	cpu.execute()
+	cpu.fetch()
+	cpu.execute()
+	if cpu.ime != Bit(1) {
+		t.Error()
+	}
+	cpu.fetch()
+	cpu.execute()
+
+	// EI -- no interrupt is serviced before the next instruction
+	cpu.ime = 0
+	cpu.sp = register16(0xFFFE)
+	cpu.writeByte(AddrIE, Byte(InterruptVblank))
+	cpu.writeByte(AddrIF, Byte(InterruptVblank))
+	cpu.pc = 0x06
+	cpu.step(false, 0)
+	if cpu.pc != Word(0x07) {
+		t.Error()
+	}
+	cpu.step(false, 0)
+	if cpu.pc != Word(0x08) {
+		t.Error()
+	}
+
+	// EI -- interrupt is serviced after the next instruction
+	cpu.step(false, 0)
+	if cpu.pc != InterruptVblank.Address()+1 {
+		t.Error()
+	}
+	if cpu.readWord(0xFFFC) != Word(0x08) {
+		t.Error()
+	}
+
+	// EI -- followed by HALT with an interrupt pending returns to the HALT
+	cpu.ime = 0
+	cpu.sp = register16(0xFFFE)
+	cpu.writeByte(AddrIF, Byte(InterruptVblank))
+	cpu.pc = 0x09
+	cpu.step(false, 0)
+	cpu.step(false, 0)
+	if cpu.halted != false {
+		t.Error()
+	}
+	cpu.step(false, 0)
+	if cpu.pc != InterruptVblank.Address()+1 {
+		t.Error()
+	}
+	if cpu.readWord(0xFFFC) != Word(0x0A) {
+		t.Error()
+	}
 }
 
 func TestOpFE(t *testing.T) {
@@ -1361,6 +1621,15965 @@ func TestOpFE(t *testing.T) {
 	}
 }
 
+func TestOp08(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x08, 0x80, 0xFF})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD (nn), SP
+	cpu.sp = register16(0xBEEF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0xEF) {
+		t.Error()
+	}
+	if cpu.readByte(0xFF81) != Byte(0xBE) {
+		t.Error()
+	}
+}
+
+func TestOp09(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x09})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// ADD HL, BC -- Z unaffected, H, NC
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0x8A23)
+	cpu.b.setWord(0x0605)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Word() != Word(0x9028) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADD HL, BC -- Z unaffected, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0x0FFF)
+	cpu.b.setWord(0x0001)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Word() != Word(0x1000) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADD HL, BC -- Z unaffected, NH, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0x8000)
+	cpu.b.setWord(0x8000)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Word() != Word(0x0000) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOp0A(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x0A})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD A, (BC)
+	cpu.b.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x89)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x89) {
+		t.Error()
+	}
+}
+
+func TestOp0B(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x0B})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// DEC BC
+	cpu.b.setWord(0x2000)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Word() != Word(0x1FFF) {
+		t.Error()
+	}
+
+	// DEC BC -- underflow
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.b.setWord(0x0000)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Word() != Word(0xFFFF) {
+		t.Error()
+	}
+}
+
+func TestOp0D(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x0D})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// DEC C -- NZ, NH
+	cpu.c.set(0x45)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x44) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+
+	// DEC C -- NZ, H
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.c.set(0x20)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x1F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+
+	// DEC C -- Z, NH
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.c.set(0x01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+}
+
+func TestOp0F(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x0F})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RRCA -- bit0 low, NC
+	cpu.a.set(0xFE)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x7F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// RRCA -- bit0 high, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x7F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xBF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RRCA -- zero result, Z always reset, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp12(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x12})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD (DE), A
+	cpu.a.set(0x89)
+	cpu.d.setWord(0xFF80)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x89) {
+		t.Error()
+	}
+}
+
+func TestOp14(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x14})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// INC D -- NZ, NH
+	cpu.d.set(0x44)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x45) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+
+	// INC D -- NZ, H
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.d.set(0x1F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x20) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+
+	// INC D -- Z, H
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.d.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+}
+
+func TestOp15(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x15})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// DEC D -- NZ, NH
+	cpu.d.set(0x45)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x44) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+
+	// DEC D -- NZ, H
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.d.set(0x20)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x1F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+
+	// DEC D -- Z, NH
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.d.set(0x01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+}
+
+func TestOp16(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x16, 0x32})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD D, #
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x32) {
+		t.Error()
+	}
+}
+
+func TestOp19(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x19})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// ADD HL, DE -- Z unaffected, H, NC
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0x8A23)
+	cpu.d.setWord(0x0605)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Word() != Word(0x9028) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADD HL, DE -- Z unaffected, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0x0FFF)
+	cpu.d.setWord(0x0001)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Word() != Word(0x1000) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADD HL, DE -- Z unaffected, NH, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0x8000)
+	cpu.d.setWord(0x8000)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Word() != Word(0x0000) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOp1B(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x1B})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// DEC DE
+	cpu.d.setWord(0x2000)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Word() != Word(0x1FFF) {
+		t.Error()
+	}
+
+	// DEC DE -- underflow
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.d.setWord(0x0000)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Word() != Word(0xFFFF) {
+		t.Error()
+	}
+}
+
+func TestOp1C(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x1C})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// INC E -- NZ, NH
+	cpu.e.set(0x44)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x45) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+
+	// INC E -- NZ, H
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.e.set(0x1F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x20) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+
+	// INC E -- Z, H
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.e.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+}
+
+func TestOp1D(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x1D})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// DEC E -- NZ, NH
+	cpu.e.set(0x45)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x44) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+
+	// DEC E -- NZ, H
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.e.set(0x20)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x1F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+
+	// DEC E -- Z, NH
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.e.set(0x01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+}
+
+func TestOp1E(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x1E, 0x32})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD E, #
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x32) {
+		t.Error()
+	}
+}
+
+func TestOp1F(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x1F})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RRA -- carry, bit0 low, NC
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0xFE)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xFF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// RRA -- no carry, bit0 high, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RRA -- no carry, bit0 high, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x81)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x40) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOp24(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x24})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// INC H -- NZ, NH
+	cpu.h.set(0x44)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x45) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+
+	// INC H -- NZ, H
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.set(0x1F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x20) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+
+	// INC H -- Z, H
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+}
+
+func TestOp25(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x25})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// DEC H -- NZ, NH
+	cpu.h.set(0x45)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x44) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+
+	// DEC H -- NZ, H
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.set(0x20)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x1F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+
+	// DEC H -- Z, NH
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.set(0x01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+}
+
+func TestOp26(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x26, 0x32})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD H, #
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x32) {
+		t.Error()
+	}
+}
+
+func TestOp27(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x27})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// DAA -- add, low nibble adjust
+	cpu.a.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x15) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// DAA -- add, both adjust, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x9A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// DAA -- add, H set
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagH)
+	cpu.a.set(0x22)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x28) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// DAA -- sub, H set
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagN)
+	cpu.f.setFlag(flagH)
+	cpu.a.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x09) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// DAA -- sub, C set
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagN)
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xA0) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// DAA -- zero, Z
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp29(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x29})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// ADD HL, HL -- Z unaffected, NH, NC
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0x0102)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Word() != Word(0x0204) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADD HL, HL -- Z unaffected, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0x0800)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Word() != Word(0x1000) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADD HL, HL -- Z unaffected, NH, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0x8000)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Word() != Word(0x0000) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOp2A(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x2A})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LDI A, (HL)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x89)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x89) {
+		t.Error()
+	}
+	if cpu.h.Word() != Word(0xFF81) {
+		t.Error()
+	}
+}
+
+func TestOp2B(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x2B})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// DEC HL
+	cpu.h.setWord(0x2000)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Word() != Word(0x1FFF) {
+		t.Error()
+	}
+
+	// DEC HL -- underflow
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.setWord(0x0000)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Word() != Word(0xFFFF) {
+		t.Error()
+	}
+}
+
+func TestOp2C(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x2C})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// INC L -- NZ, NH
+	cpu.l.set(0x44)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x45) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+
+	// INC L -- NZ, H
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.l.set(0x1F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x20) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+
+	// INC L -- Z, H
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.l.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+}
+
+func TestOp2D(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x2D})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// DEC L -- NZ, NH
+	cpu.l.set(0x45)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x44) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+
+	// DEC L -- NZ, H
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.l.set(0x20)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x1F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+
+	// DEC L -- Z, NH
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.l.set(0x01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+}
+
+func TestOp2E(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x2E, 0x32})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD L, #
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x32) {
+		t.Error()
+	}
+}
+
+func TestOp2F(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x2F})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// CPL
+	cpu.a.set(0x35)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xCA) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+}
+
+func TestOp30(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x00, 0x00, 0x00, 0x00, 0x30, 0x05, 0x30, 0xFC})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// JR NC, * -- NC, positive offset
+	cpu.pc = register16(0x04)
+	cpu.f.resetFlag(flagC)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x04+0x02+0x05) {
+		t.Error()
+	}
+
+	// JR NC, * -- NC, negative offset
+	cpu.f.reset()
+	cpu.pc = register16(0x06)
+	cpu.f.resetFlag(flagC)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x06+0x02-0x04) {
+		t.Error()
+	}
+
+	// JR NC, * -- not NC
+	cpu.f.reset()
+	cpu.pc = register16(0x04)
+	cpu.f.setFlag(flagC)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x04+0x02) {
+		t.Error()
+	}
+}
+
+func TestOp33(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x33})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// INC SP
+	cpu.sp = register16(0x1FFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.sp != Word(0x2000) {
+		t.Error()
+	}
+
+	// INC SP -- overflow
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.sp = register16(0xFFFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.sp != Word(0x0000) {
+		t.Error()
+	}
+}
+
+func TestOp34(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x34})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// INC (HL) -- NZ, NH
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x44)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x45) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+
+	// INC (HL) -- NZ, H
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x1F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x20) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+
+	// INC (HL) -- Z, H
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+}
+
+func TestOp35(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x35})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// DEC (HL) -- NZ, NH
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x45)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x44) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+
+	// DEC (HL) -- NZ, H
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x20)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x1F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+
+	// DEC (HL) -- Z, NH
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+}
+
+func TestOp36(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x36, 0x32})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD (HL), #
+	cpu.h.setWord(0xFF80)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x32) {
+		t.Error()
+	}
+}
+
+func TestOp37(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x37})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SCF
+	cpu.f.setFlag(flagN)
+	cpu.f.setFlag(flagH)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOp38(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x00, 0x00, 0x00, 0x00, 0x38, 0x05, 0x38, 0xFC})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// JR C, * -- C, positive offset
+	cpu.pc = register16(0x04)
+	cpu.f.setFlag(flagC)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x04+0x02+0x05) {
+		t.Error()
+	}
+
+	// JR C, * -- C, negative offset
+	cpu.f.reset()
+	cpu.pc = register16(0x06)
+	cpu.f.setFlag(flagC)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x06+0x02-0x04) {
+		t.Error()
+	}
+
+	// JR C, * -- not C
+	cpu.f.reset()
+	cpu.pc = register16(0x04)
+	cpu.f.resetFlag(flagC)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x04+0x02) {
+		t.Error()
+	}
+}
+
+func TestOp39(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x39})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// ADD HL, SP -- Z unaffected, H, NC
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0x8A23)
+	cpu.sp = register16(0x0605)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Word() != Word(0x9028) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADD HL, SP -- Z unaffected, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0x0FFF)
+	cpu.sp = register16(0x0001)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Word() != Word(0x1000) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADD HL, SP -- Z unaffected, NH, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0x8000)
+	cpu.sp = register16(0x8000)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Word() != Word(0x0000) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOp3A(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x3A})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LDD A, (HL)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x89)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x89) {
+		t.Error()
+	}
+	if cpu.h.Word() != Word(0xFF7F) {
+		t.Error()
+	}
+}
+
+func TestOp3B(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x3B})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// DEC SP
+	cpu.sp = register16(0x2000)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.sp != Word(0x1FFF) {
+		t.Error()
+	}
+
+	// DEC SP -- underflow
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.sp = register16(0x0000)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.sp != Word(0xFFFF) {
+		t.Error()
+	}
+}
+
+func TestOp3C(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x3C})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// INC A -- NZ, NH
+	cpu.a.set(0x44)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x45) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+
+	// INC A -- NZ, H
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x1F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x20) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+
+	// INC A -- Z, H
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+}
+
+func TestOp3D(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x3D})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// DEC A -- NZ, NH
+	cpu.a.set(0x45)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x44) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+
+	// DEC A -- NZ, H
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x20)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x1F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+
+	// DEC A -- Z, NH
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+}
+
+func TestOp3F(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x3F})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// CCF -- NC to C
+	cpu.f.setFlag(flagN)
+	cpu.f.setFlag(flagH)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// CCF -- C to NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp40(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x40})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD B, B
+	cpu.b.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp41(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x41})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD B, C
+	cpu.c.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp42(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x42})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD B, D
+	cpu.d.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp43(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x43})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD B, E
+	cpu.e.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp44(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x44})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD B, H
+	cpu.h.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp45(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x45})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD B, L
+	cpu.l.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp46(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x46})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD B, (HL)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp47(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x47})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD B, A
+	cpu.a.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp48(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x48})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD C, B
+	cpu.b.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp49(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x49})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD C, C
+	cpu.c.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp4A(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x4A})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD C, D
+	cpu.d.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp4B(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x4B})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD C, E
+	cpu.e.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp4C(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x4C})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD C, H
+	cpu.h.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp4D(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x4D})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD C, L
+	cpu.l.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp4E(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x4E})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD C, (HL)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp50(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x50})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD D, B
+	cpu.b.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp51(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x51})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD D, C
+	cpu.c.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp52(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x52})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD D, D
+	cpu.d.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp53(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x53})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD D, E
+	cpu.e.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp54(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x54})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD D, H
+	cpu.h.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp55(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x55})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD D, L
+	cpu.l.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp56(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x56})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD D, (HL)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp57(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x57})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD D, A
+	cpu.a.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp58(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x58})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD E, B
+	cpu.b.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp59(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x59})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD E, C
+	cpu.c.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp5A(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x5A})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD E, D
+	cpu.d.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp5B(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x5B})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD E, E
+	cpu.e.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp5C(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x5C})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD E, H
+	cpu.h.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp5D(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x5D})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD E, L
+	cpu.l.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp5E(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x5E})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD E, (HL)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp5F(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x5F})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD E, A
+	cpu.a.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp60(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x60})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD H, B
+	cpu.b.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp61(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x61})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD H, C
+	cpu.c.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp62(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x62})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD H, D
+	cpu.d.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp63(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x63})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD H, E
+	cpu.e.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp64(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x64})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD H, H
+	cpu.h.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp65(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x65})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD H, L
+	cpu.l.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp66(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x66})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD H, (HL)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp67(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x67})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD H, A
+	cpu.a.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp68(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x68})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD L, B
+	cpu.b.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp69(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x69})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD L, C
+	cpu.c.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp6A(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x6A})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD L, D
+	cpu.d.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp6B(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x6B})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD L, E
+	cpu.e.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp6C(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x6C})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD L, H
+	cpu.h.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp6D(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x6D})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD L, L
+	cpu.l.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp6E(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x6E})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD L, (HL)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp6F(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x6F})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD L, A
+	cpu.a.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp70(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x70})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD (HL), B
+	cpu.h.setWord(0xFF80)
+	cpu.b.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp71(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x71})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD (HL), C
+	cpu.h.setWord(0xFF80)
+	cpu.c.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp72(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x72})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD (HL), D
+	cpu.h.setWord(0xFF80)
+	cpu.d.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp73(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x73})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD (HL), E
+	cpu.h.setWord(0xFF80)
+	cpu.e.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp74(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x74})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD (HL), H
+	cpu.h.setWord(0xFF80)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0xFF) {
+		t.Error()
+	}
+}
+
+func TestOp75(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x75})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD (HL), L
+	cpu.h.setWord(0xFF80)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x80) {
+		t.Error()
+	}
+}
+
+func TestOp78(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x78})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD A, B
+	cpu.b.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp79(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x79})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD A, C
+	cpu.c.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp7A(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x7A})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD A, D
+	cpu.d.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp7B(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x7B})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD A, E
+	cpu.e.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp7C(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x7C})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD A, H
+	cpu.h.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp7D(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x7D})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD A, L
+	cpu.l.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp7E(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x7E})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD A, (HL)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp7F(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x7F})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD A, A
+	cpu.a.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+}
+
+func TestOp80(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x80})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// ADD A, B -- Z, NN, H, C
+	cpu.a.set(0x3A)
+	cpu.b.set(0xC6)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// ADD A, B -- NZ, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x0F)
+	cpu.b.set(0x01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADD A, B -- NZ, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x12)
+	cpu.b.set(0x34)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x46) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp81(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x81})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// ADD A, C -- Z, NN, H, C
+	cpu.a.set(0x3A)
+	cpu.c.set(0xC6)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// ADD A, C -- NZ, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x0F)
+	cpu.c.set(0x01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADD A, C -- NZ, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x12)
+	cpu.c.set(0x34)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x46) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp82(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x82})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// ADD A, D -- Z, NN, H, C
+	cpu.a.set(0x3A)
+	cpu.d.set(0xC6)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// ADD A, D -- NZ, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x0F)
+	cpu.d.set(0x01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADD A, D -- NZ, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x12)
+	cpu.d.set(0x34)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x46) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp83(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x83})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// ADD A, E -- Z, NN, H, C
+	cpu.a.set(0x3A)
+	cpu.e.set(0xC6)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// ADD A, E -- NZ, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x0F)
+	cpu.e.set(0x01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADD A, E -- NZ, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x12)
+	cpu.e.set(0x34)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x46) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp84(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x84})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// ADD A, H -- Z, NN, H, C
+	cpu.a.set(0x3A)
+	cpu.h.set(0xC6)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// ADD A, H -- NZ, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x0F)
+	cpu.h.set(0x01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADD A, H -- NZ, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x12)
+	cpu.h.set(0x34)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x46) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp85(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x85})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// ADD A, L -- Z, NN, H, C
+	cpu.a.set(0x3A)
+	cpu.l.set(0xC6)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// ADD A, L -- NZ, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x0F)
+	cpu.l.set(0x01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADD A, L -- NZ, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x12)
+	cpu.l.set(0x34)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x46) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp86(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x86})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// ADD A, (HL) -- Z, NN, H, C
+	cpu.a.set(0x3A)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0xC6)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// ADD A, (HL) -- NZ, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x0F)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADD A, (HL) -- NZ, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x12)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x34)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x46) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp87(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x87})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// ADD A, A -- NZ, NN, H, NC
+	cpu.a.set(0x3A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x74) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADD A, A -- NZ, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x1E) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp88(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x88})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// ADC A, B -- Z, NN, H, C
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0xE1)
+	cpu.b.set(0x1E)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// ADC A, B -- NZ, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x0F)
+	cpu.b.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADC A, B -- NZ, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x12)
+	cpu.b.set(0x34)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x46) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp89(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x89})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// ADC A, C -- Z, NN, H, C
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0xE1)
+	cpu.c.set(0x1E)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// ADC A, C -- NZ, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x0F)
+	cpu.c.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADC A, C -- NZ, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x12)
+	cpu.c.set(0x34)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x46) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp8A(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x8A})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// ADC A, D -- Z, NN, H, C
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0xE1)
+	cpu.d.set(0x1E)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// ADC A, D -- NZ, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x0F)
+	cpu.d.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADC A, D -- NZ, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x12)
+	cpu.d.set(0x34)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x46) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp8B(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x8B})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// ADC A, E -- Z, NN, H, C
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0xE1)
+	cpu.e.set(0x1E)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// ADC A, E -- NZ, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x0F)
+	cpu.e.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADC A, E -- NZ, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x12)
+	cpu.e.set(0x34)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x46) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp8C(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x8C})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// ADC A, H -- Z, NN, H, C
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0xE1)
+	cpu.h.set(0x1E)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// ADC A, H -- NZ, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x0F)
+	cpu.h.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADC A, H -- NZ, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x12)
+	cpu.h.set(0x34)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x46) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp8D(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x8D})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// ADC A, L -- Z, NN, H, C
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0xE1)
+	cpu.l.set(0x1E)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// ADC A, L -- NZ, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x0F)
+	cpu.l.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADC A, L -- NZ, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x12)
+	cpu.l.set(0x34)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x46) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp8E(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x8E})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// ADC A, (HL) -- Z, NN, H, C
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0xE1)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x1E)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// ADC A, (HL) -- NZ, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x0F)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADC A, (HL) -- NZ, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x12)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x34)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x46) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp8F(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x8F})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// ADC A, A -- NZ, NN, NH, C
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0xE1)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xC3) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// ADC A, A -- NZ, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x1F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp90(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x90})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SUB B -- Z, N, NH, NC
+	cpu.a.set(0x3E)
+	cpu.b.set(0x3E)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SUB B -- NZ, N, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3E)
+	cpu.b.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x2F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SUB B -- NZ, N, NH, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3E)
+	cpu.b.set(0x40)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xFE) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOp91(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x91})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SUB C -- Z, N, NH, NC
+	cpu.a.set(0x3E)
+	cpu.c.set(0x3E)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SUB C -- NZ, N, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3E)
+	cpu.c.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x2F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SUB C -- NZ, N, NH, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3E)
+	cpu.c.set(0x40)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xFE) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOp92(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x92})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SUB D -- Z, N, NH, NC
+	cpu.a.set(0x3E)
+	cpu.d.set(0x3E)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SUB D -- NZ, N, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3E)
+	cpu.d.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x2F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SUB D -- NZ, N, NH, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3E)
+	cpu.d.set(0x40)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xFE) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOp93(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x93})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SUB E -- Z, N, NH, NC
+	cpu.a.set(0x3E)
+	cpu.e.set(0x3E)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SUB E -- NZ, N, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3E)
+	cpu.e.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x2F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SUB E -- NZ, N, NH, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3E)
+	cpu.e.set(0x40)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xFE) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOp94(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x94})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SUB H -- Z, N, NH, NC
+	cpu.a.set(0x3E)
+	cpu.h.set(0x3E)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SUB H -- NZ, N, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3E)
+	cpu.h.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x2F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SUB H -- NZ, N, NH, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3E)
+	cpu.h.set(0x40)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xFE) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOp95(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x95})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SUB L -- Z, N, NH, NC
+	cpu.a.set(0x3E)
+	cpu.l.set(0x3E)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SUB L -- NZ, N, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3E)
+	cpu.l.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x2F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SUB L -- NZ, N, NH, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3E)
+	cpu.l.set(0x40)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xFE) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOp96(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x96})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SUB (HL) -- Z, N, NH, NC
+	cpu.a.set(0x3E)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x3E)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SUB (HL) -- NZ, N, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3E)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x2F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SUB (HL) -- NZ, N, NH, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3E)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x40)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xFE) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOp97(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x97})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SUB A -- Z, N, NH, NC
+	cpu.a.set(0x3E)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SUB A -- Z, N, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3E)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp98(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x98})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SBC A, B -- NZ, N, NH, NC
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x3B)
+	cpu.b.set(0x2A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SBC A, B -- NZ, N, H, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x3B)
+	cpu.b.set(0x4F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xEB) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SBC A, B -- Z, N, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x10)
+	cpu.b.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp99(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x99})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SBC A, C -- NZ, N, NH, NC
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x3B)
+	cpu.c.set(0x2A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SBC A, C -- NZ, N, H, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x3B)
+	cpu.c.set(0x4F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xEB) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SBC A, C -- Z, N, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x10)
+	cpu.c.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp9A(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x9A})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SBC A, D -- NZ, N, NH, NC
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x3B)
+	cpu.d.set(0x2A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SBC A, D -- NZ, N, H, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x3B)
+	cpu.d.set(0x4F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xEB) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SBC A, D -- Z, N, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x10)
+	cpu.d.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp9B(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x9B})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SBC A, E -- NZ, N, NH, NC
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x3B)
+	cpu.e.set(0x2A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SBC A, E -- NZ, N, H, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x3B)
+	cpu.e.set(0x4F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xEB) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SBC A, E -- Z, N, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x10)
+	cpu.e.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp9C(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x9C})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SBC A, H -- NZ, N, NH, NC
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x3B)
+	cpu.h.set(0x2A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SBC A, H -- NZ, N, H, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x3B)
+	cpu.h.set(0x4F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xEB) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SBC A, H -- Z, N, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x10)
+	cpu.h.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp9D(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x9D})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SBC A, L -- NZ, N, NH, NC
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x3B)
+	cpu.l.set(0x2A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SBC A, L -- NZ, N, H, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x3B)
+	cpu.l.set(0x4F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xEB) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SBC A, L -- Z, N, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x10)
+	cpu.l.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp9E(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x9E})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SBC A, (HL) -- NZ, N, NH, NC
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x3B)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x2A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SBC A, (HL) -- NZ, N, H, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x3B)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x4F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xEB) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SBC A, (HL) -- Z, N, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x10)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOp9F(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x9F})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SBC A, A -- NZ, N, H, C
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x3B)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xFF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SBC A, A -- NZ, N, H, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x3B)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xFF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpA0(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xA0})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// AND B -- NZ, NN, H, NC
+	cpu.a.set(0x5A)
+	cpu.b.set(0x3F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x1A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// AND B -- Z, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x5A)
+	cpu.b.set(0xA5)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpA1(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xA1})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// AND C -- NZ, NN, H, NC
+	cpu.a.set(0x5A)
+	cpu.c.set(0x3F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x1A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// AND C -- Z, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x5A)
+	cpu.c.set(0xA5)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpA2(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xA2})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// AND D -- NZ, NN, H, NC
+	cpu.a.set(0x5A)
+	cpu.d.set(0x3F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x1A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// AND D -- Z, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x5A)
+	cpu.d.set(0xA5)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpA3(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xA3})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// AND E -- NZ, NN, H, NC
+	cpu.a.set(0x5A)
+	cpu.e.set(0x3F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x1A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// AND E -- Z, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x5A)
+	cpu.e.set(0xA5)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpA4(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xA4})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// AND H -- NZ, NN, H, NC
+	cpu.a.set(0x5A)
+	cpu.h.set(0x3F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x1A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// AND H -- Z, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x5A)
+	cpu.h.set(0xA5)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpA5(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xA5})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// AND L -- NZ, NN, H, NC
+	cpu.a.set(0x5A)
+	cpu.l.set(0x3F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x1A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// AND L -- Z, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x5A)
+	cpu.l.set(0xA5)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpA6(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xA6})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// AND (HL) -- NZ, NN, H, NC
+	cpu.a.set(0x5A)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x3F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x1A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// AND (HL) -- Z, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x5A)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0xA5)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpA7(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xA7})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// AND A -- NZ, NN, H, NC
+	cpu.a.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// AND A -- NZ, NN, H, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpA9(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xA9})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// XOR C -- NZ, NN, NH, NC
+	cpu.a.set(0xFF)
+	cpu.c.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xF0) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// XOR C -- Z, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x5A)
+	cpu.c.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpAA(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xAA})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// XOR D -- NZ, NN, NH, NC
+	cpu.a.set(0xFF)
+	cpu.d.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xF0) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// XOR D -- Z, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x5A)
+	cpu.d.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpAB(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xAB})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// XOR E -- NZ, NN, NH, NC
+	cpu.a.set(0xFF)
+	cpu.e.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xF0) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// XOR E -- Z, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x5A)
+	cpu.e.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpAC(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xAC})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// XOR H -- NZ, NN, NH, NC
+	cpu.a.set(0xFF)
+	cpu.h.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xF0) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// XOR H -- Z, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x5A)
+	cpu.h.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpAD(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xAD})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// XOR L -- NZ, NN, NH, NC
+	cpu.a.set(0xFF)
+	cpu.l.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xF0) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// XOR L -- Z, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x5A)
+	cpu.l.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpAE(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xAE})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// XOR (HL) -- NZ, NN, NH, NC
+	cpu.a.set(0xFF)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xF0) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// XOR (HL) -- Z, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x5A)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpB0(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xB0})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// OR B -- NZ, NN, NH, NC
+	cpu.a.set(0x5A)
+	cpu.b.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x5F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// OR B -- Z, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x00)
+	cpu.b.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpB2(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xB2})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// OR D -- NZ, NN, NH, NC
+	cpu.a.set(0x5A)
+	cpu.d.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x5F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// OR D -- Z, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x00)
+	cpu.d.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpB3(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xB3})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// OR E -- NZ, NN, NH, NC
+	cpu.a.set(0x5A)
+	cpu.e.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x5F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// OR E -- Z, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x00)
+	cpu.e.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpB4(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xB4})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// OR H -- NZ, NN, NH, NC
+	cpu.a.set(0x5A)
+	cpu.h.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x5F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// OR H -- Z, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x00)
+	cpu.h.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpB5(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xB5})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// OR L -- NZ, NN, NH, NC
+	cpu.a.set(0x5A)
+	cpu.l.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x5F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// OR L -- Z, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x00)
+	cpu.l.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpB6(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xB6})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// OR (HL) -- NZ, NN, NH, NC
+	cpu.a.set(0x5A)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x5F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// OR (HL) -- Z, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x00)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpB7(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xB7})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// OR A -- NZ, NN, NH, NC
+	cpu.a.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x5A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// OR A -- Z, NN, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpB8(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xB8})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// CP B -- NZ, N, H, NC
+	cpu.a.set(0x3C)
+	cpu.b.set(0x2F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x3C) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// CP B -- Z, N, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3C)
+	cpu.b.set(0x3C)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x3C) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// CP B -- NZ, N, NH, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3C)
+	cpu.b.set(0x40)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x3C) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpB9(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xB9})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// CP C -- NZ, N, H, NC
+	cpu.a.set(0x3C)
+	cpu.c.set(0x2F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x3C) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// CP C -- Z, N, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3C)
+	cpu.c.set(0x3C)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x3C) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// CP C -- NZ, N, NH, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3C)
+	cpu.c.set(0x40)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x3C) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpBA(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xBA})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// CP D -- NZ, N, H, NC
+	cpu.a.set(0x3C)
+	cpu.d.set(0x2F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x3C) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// CP D -- Z, N, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3C)
+	cpu.d.set(0x3C)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x3C) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// CP D -- NZ, N, NH, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3C)
+	cpu.d.set(0x40)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x3C) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpBB(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xBB})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// CP E -- NZ, N, H, NC
+	cpu.a.set(0x3C)
+	cpu.e.set(0x2F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x3C) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// CP E -- Z, N, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3C)
+	cpu.e.set(0x3C)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x3C) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// CP E -- NZ, N, NH, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3C)
+	cpu.e.set(0x40)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x3C) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpBC(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xBC})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// CP H -- NZ, N, H, NC
+	cpu.a.set(0x3C)
+	cpu.h.set(0x2F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x3C) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// CP H -- Z, N, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3C)
+	cpu.h.set(0x3C)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x3C) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// CP H -- NZ, N, NH, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3C)
+	cpu.h.set(0x40)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x3C) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpBD(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xBD})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// CP L -- NZ, N, H, NC
+	cpu.a.set(0x3C)
+	cpu.l.set(0x2F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x3C) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// CP L -- Z, N, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3C)
+	cpu.l.set(0x3C)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x3C) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// CP L -- NZ, N, NH, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3C)
+	cpu.l.set(0x40)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x3C) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpBE(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xBE})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// CP (HL) -- NZ, N, H, NC
+	cpu.a.set(0x3C)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x2F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x3C) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// CP (HL) -- Z, N, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3C)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x3C)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x3C) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// CP (HL) -- NZ, N, NH, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3C)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x40)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x3C) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpBF(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xBF})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// CP A -- Z, N, NH, NC
+	cpu.a.set(0x3C)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x3C) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// CP A -- Z, N, NH, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x3C)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x3C) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpC0(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xC0})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RET NZ -- taken
+	cpu.sp = register16(0xFFFC)
+	cpu.writeWord(0xFFFC, 0x0F01)
+	cpu.f.resetFlag(flagZ)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0F01) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFE) {
+		t.Error()
+	}
+
+	// RET NZ -- not taken
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.sp = register16(0xFFFC)
+	cpu.f.setFlag(flagZ)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0001) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFC) {
+		t.Error()
+	}
+}
+
+func TestOpC2(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xC2, 0x21, 0x67})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// JP NZ, nn -- taken
+	cpu.f.resetFlag(flagZ)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x6721) {
+		t.Error()
+	}
+
+	// JP NZ, nn -- not taken
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagZ)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0003) {
+		t.Error()
+	}
+}
+
+func TestOpC3(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xC3, 0x21, 0x67})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// JP nn
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x6721) {
+		t.Error()
+	}
+}
+
+func TestOpC4(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xC4, 0x21, 0x67})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// CALL NZ, nn -- taken
+	cpu.sp = register16(0xFFFE)
+	cpu.f.resetFlag(flagZ)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x6721) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFC) {
+		t.Error()
+	}
+	if cpu.readWord(0xFFFC) != Word(0x0003) {
+		t.Error()
+	}
+
+	// CALL NZ, nn -- not taken
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.sp = register16(0xFFFE)
+	cpu.f.setFlag(flagZ)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0003) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFE) {
+		t.Error()
+	}
+}
+
+func TestOpC6(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xC6, 0xC6, 0xC6, 0x01, 0xC6, 0x34})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// ADD A, # -- Z, NN, H, C
+	cpu.a.set(0x3A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// ADD A, # -- NZ, NN, H, NC
+	cpu.f.reset()
+	cpu.a.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADD A, # -- NZ, NN, NH, NC
+	cpu.f.reset()
+	cpu.a.set(0x12)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x46) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpC7(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x00, 0x00, 0x00, 0xC7})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RST 00H
+	cpu.pc = register16(0x03)
+	cpu.sp = register16(0xFFFE)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0000) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFC) {
+		t.Error()
+	}
+	if cpu.readWord(0xFFFC) != Word(0x0004) {
+		t.Error()
+	}
+}
+
+func TestOpC8(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xC8})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RET Z -- taken
+	cpu.sp = register16(0xFFFC)
+	cpu.writeWord(0xFFFC, 0x0F01)
+	cpu.f.setFlag(flagZ)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0F01) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFE) {
+		t.Error()
+	}
+
+	// RET Z -- not taken
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.sp = register16(0xFFFC)
+	cpu.f.resetFlag(flagZ)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0001) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFC) {
+		t.Error()
+	}
+}
+
+func TestOpCA(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCA, 0x21, 0x67})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// JP Z, nn -- taken
+	cpu.f.setFlag(flagZ)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x6721) {
+		t.Error()
+	}
+
+	// JP Z, nn -- not taken
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.resetFlag(flagZ)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0003) {
+		t.Error()
+	}
+}
+
+func TestOpCC(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCC, 0x21, 0x67})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// CALL Z, nn -- taken
+	cpu.sp = register16(0xFFFE)
+	cpu.f.setFlag(flagZ)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x6721) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFC) {
+		t.Error()
+	}
+	if cpu.readWord(0xFFFC) != Word(0x0003) {
+		t.Error()
+	}
+
+	// CALL Z, nn -- not taken
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.sp = register16(0xFFFE)
+	cpu.f.resetFlag(flagZ)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0003) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFE) {
+		t.Error()
+	}
+}
+
+func TestOpCE(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCE, 0x1E, 0xCE, 0x00, 0xCE, 0x34})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// ADC A, # -- Z, NN, H, C
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0xE1)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// ADC A, # -- NZ, NN, H, NC
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x0F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADC A, # -- NZ, NN, NH, NC
+	cpu.f.reset()
+	cpu.a.set(0x12)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x46) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCF(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x00, 0x00, 0x00, 0xCF})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RST 08H
+	cpu.pc = register16(0x03)
+	cpu.sp = register16(0xFFFE)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0008) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFC) {
+		t.Error()
+	}
+	if cpu.readWord(0xFFFC) != Word(0x0004) {
+		t.Error()
+	}
+}
+
+func TestOpD0(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xD0})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RET NC -- taken
+	cpu.sp = register16(0xFFFC)
+	cpu.writeWord(0xFFFC, 0x0F01)
+	cpu.f.resetFlag(flagC)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0F01) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFE) {
+		t.Error()
+	}
+
+	// RET NC -- not taken
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.sp = register16(0xFFFC)
+	cpu.f.setFlag(flagC)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0001) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFC) {
+		t.Error()
+	}
+}
+
+func TestOpD1(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xD1})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// POP DE
+	cpu.sp = register16(0xFFFC)
+	cpu.writeWord(0xFFFC, 0x2003)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.sp != Word(0xFFFE) {
+		t.Error()
+	}
+	if cpu.d.Word() != Word(0x2003) {
+		t.Error()
+	}
+}
+
+func TestOpD2(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xD2, 0x21, 0x67})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// JP NC, nn -- taken
+	cpu.f.resetFlag(flagC)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x6721) {
+		t.Error()
+	}
+
+	// JP NC, nn -- not taken
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0003) {
+		t.Error()
+	}
+}
+
+func TestOpD4(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xD4, 0x21, 0x67})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// CALL NC, nn -- taken
+	cpu.sp = register16(0xFFFE)
+	cpu.f.resetFlag(flagC)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x6721) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFC) {
+		t.Error()
+	}
+	if cpu.readWord(0xFFFC) != Word(0x0003) {
+		t.Error()
+	}
+
+	// CALL NC, nn -- not taken
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.sp = register16(0xFFFE)
+	cpu.f.setFlag(flagC)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0003) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFE) {
+		t.Error()
+	}
+}
+
+func TestOpD5(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xD5})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// PUSH DE
+	cpu.sp = register16(0xFFFE)
+	cpu.d.setWord(0x6040)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.sp != Word(0xFFFC) {
+		t.Error()
+	}
+	if cpu.readWord(0xFFFC) != Word(0x6040) {
+		t.Error()
+	}
+}
+
+func TestOpD6(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xD6, 0x3E, 0xD6, 0x0F, 0xD6, 0x40})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SUB # -- Z, N, NH, NC
+	cpu.a.set(0x3E)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SUB # -- NZ, N, H, NC
+	cpu.f.reset()
+	cpu.a.set(0x3E)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x2F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SUB # -- NZ, N, NH, C
+	cpu.f.reset()
+	cpu.a.set(0x3E)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xFE) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpD7(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x00, 0x00, 0x00, 0xD7})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RST 10H
+	cpu.pc = register16(0x03)
+	cpu.sp = register16(0xFFFE)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0010) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFC) {
+		t.Error()
+	}
+	if cpu.readWord(0xFFFC) != Word(0x0004) {
+		t.Error()
+	}
+}
+
+func TestOpD8(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xD8})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RET C -- taken
+	cpu.sp = register16(0xFFFC)
+	cpu.writeWord(0xFFFC, 0x0F01)
+	cpu.f.setFlag(flagC)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0F01) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFE) {
+		t.Error()
+	}
+
+	// RET C -- not taken
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.sp = register16(0xFFFC)
+	cpu.f.resetFlag(flagC)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0001) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFC) {
+		t.Error()
+	}
+}
+
+func TestOpD9(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xD9})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RETI -- enables interrupts immediately
+	cpu.ime = 0
+	cpu.sp = register16(0xFFFC)
+	cpu.writeWord(cpu.sp, 0x0F01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0F01) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFE) {
+		t.Error()
+	}
+	if cpu.ime != Bit(1) {
+		t.Error()
+	}
+}
+
+func TestOpDA(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xDA, 0x21, 0x67})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// JP C, nn -- taken
+	cpu.f.setFlag(flagC)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x6721) {
+		t.Error()
+	}
+
+	// JP C, nn -- not taken
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.resetFlag(flagC)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0003) {
+		t.Error()
+	}
+}
+
+func TestOpDC(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xDC, 0x21, 0x67})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// CALL C, nn -- taken
+	cpu.sp = register16(0xFFFE)
+	cpu.f.setFlag(flagC)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x6721) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFC) {
+		t.Error()
+	}
+	if cpu.readWord(0xFFFC) != Word(0x0003) {
+		t.Error()
+	}
+
+	// CALL C, nn -- not taken
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.sp = register16(0xFFFE)
+	cpu.f.resetFlag(flagC)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0003) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFE) {
+		t.Error()
+	}
+}
+
+func TestOpDE(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xDE, 0x2A, 0xDE, 0x4F, 0xDE, 0x0F})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SBC A, # -- NZ, N, NH, NC
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x3B)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SBC A, # -- NZ, N, H, C
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x3B)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xEB) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SBC A, # -- Z, N, H, NC
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x10)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpDF(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x00, 0x00, 0x00, 0xDF})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RST 18H
+	cpu.pc = register16(0x03)
+	cpu.sp = register16(0xFFFE)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0018) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFC) {
+		t.Error()
+	}
+	if cpu.readWord(0xFFFC) != Word(0x0004) {
+		t.Error()
+	}
+}
+
+func TestOpE1(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xE1})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// POP HL
+	cpu.sp = register16(0xFFFC)
+	cpu.writeWord(0xFFFC, 0x2003)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.sp != Word(0xFFFE) {
+		t.Error()
+	}
+	if cpu.h.Word() != Word(0x2003) {
+		t.Error()
+	}
+}
+
+func TestOpE5(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xE5})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// PUSH HL
+	cpu.sp = register16(0xFFFE)
+	cpu.h.setWord(0x6040)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.sp != Word(0xFFFC) {
+		t.Error()
+	}
+	if cpu.readWord(0xFFFC) != Word(0x6040) {
+		t.Error()
+	}
+}
+
+func TestOpE7(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x00, 0x00, 0x00, 0xE7})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RST 20H
+	cpu.pc = register16(0x03)
+	cpu.sp = register16(0xFFFE)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0020) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFC) {
+		t.Error()
+	}
+	if cpu.readWord(0xFFFC) != Word(0x0004) {
+		t.Error()
+	}
+}
+
+func TestOpE8(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xE8, 0x08, 0xE8, 0x01, 0xE8, 0xFE, 0xE8, 0xFF})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// ADD SP, n -- H, C
+	cpu.f.setFlag(flagZ)
+	cpu.f.setFlag(flagN)
+	cpu.sp = register16(0xFFF8)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.sp != Word(0x0000) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// ADD SP, n -- H, NC
+	cpu.f.reset()
+	cpu.f.setFlag(flagZ)
+	cpu.f.setFlag(flagN)
+	cpu.sp = register16(0x000F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.sp != Word(0x0010) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// ADD SP, n -- H, C
+	cpu.f.reset()
+	cpu.f.setFlag(flagZ)
+	cpu.f.setFlag(flagN)
+	cpu.sp = register16(0x0005)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.sp != Word(0x0003) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// ADD SP, n -- NH, NC
+	cpu.f.reset()
+	cpu.f.setFlag(flagZ)
+	cpu.f.setFlag(flagN)
+	cpu.sp = register16(0x1000)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.sp != Word(0x0FFF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpE9(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xE9})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// JP (HL)
+	cpu.h.setWord(0x6721)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x6721) {
+		t.Error()
+	}
+}
+
+func TestOpEA(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xEA, 0x80, 0xFF})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD (nn), A
+	cpu.a.set(0x89)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x89) {
+		t.Error()
+	}
+}
+
+func TestOpEE(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xEE, 0x0F, 0xEE, 0x5A})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// XOR # -- NZ, NN, NH, NC
+	cpu.a.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xF0) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// XOR # -- Z, NN, NH, NC
+	cpu.f.reset()
+	cpu.a.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpEF(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x00, 0x00, 0x00, 0xEF})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RST 28H
+	cpu.pc = register16(0x03)
+	cpu.sp = register16(0xFFFE)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0028) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFC) {
+		t.Error()
+	}
+	if cpu.readWord(0xFFFC) != Word(0x0004) {
+		t.Error()
+	}
+}
+
+func TestOpF1(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xF1})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// POP AF
+	cpu.sp = register16(0xFFFC)
+	cpu.writeWord(0xFFFC, 0x20F3)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.sp != Word(0xFFFE) {
+		t.Error()
+	}
+	if cpu.a.Word() != Word(0x20F0) {
+		t.Error()
+	}
+}
+
+func TestOpF2(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xF2})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD A, (C)
+	cpu.c.set(0x80)
+	cpu.writeByte(0xFF80, 0x89)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x89) {
+		t.Error()
+	}
+}
+
+func TestOpF5(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xF5})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// PUSH AF
+	cpu.sp = register16(0xFFFE)
+	cpu.a.setWord(0x6040)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.sp != Word(0xFFFC) {
+		t.Error()
+	}
+	if cpu.readWord(0xFFFC) != Word(0x6040) {
+		t.Error()
+	}
+}
+
+func TestOpF6(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xF6, 0x0F, 0xF6, 0x00})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// OR # -- NZ, NN, NH, NC
+	cpu.a.set(0x5A)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x5F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// OR # -- Z, NN, NH, NC
+	cpu.f.reset()
+	cpu.a.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpF7(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x00, 0x00, 0x00, 0xF7})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RST 30H
+	cpu.pc = register16(0x03)
+	cpu.sp = register16(0xFFFE)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0030) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFC) {
+		t.Error()
+	}
+	if cpu.readWord(0xFFFC) != Word(0x0004) {
+		t.Error()
+	}
+}
+
+func TestOpF8(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xF8, 0x08, 0xF8, 0x01, 0xF8, 0xFE, 0xF8, 0xFF})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LDHL SP, n -- H, C
+	cpu.f.setFlag(flagZ)
+	cpu.f.setFlag(flagN)
+	cpu.sp = register16(0xFFF8)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Word() != Word(0x0000) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// LDHL SP, n -- H, NC
+	cpu.f.reset()
+	cpu.f.setFlag(flagZ)
+	cpu.f.setFlag(flagN)
+	cpu.sp = register16(0x000F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Word() != Word(0x0010) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// LDHL SP, n -- H, C
+	cpu.f.reset()
+	cpu.f.setFlag(flagZ)
+	cpu.f.setFlag(flagN)
+	cpu.sp = register16(0x0005)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Word() != Word(0x0003) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// LDHL SP, n -- NH, NC
+	cpu.f.reset()
+	cpu.f.setFlag(flagZ)
+	cpu.f.setFlag(flagN)
+	cpu.sp = register16(0x1000)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Word() != Word(0x0FFF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpF9(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xF9})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD SP, HL
+	cpu.h.setWord(0xDFF0)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.sp != Word(0xDFF0) {
+		t.Error()
+	}
+}
+
+func TestOpFA(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xFA, 0x80, 0xFF})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LD A, (nn)
+	cpu.writeByte(0xFF80, 0x89)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x89) {
+		t.Error()
+	}
+}
+
+func TestOpFF(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x00, 0x00, 0x00, 0xFF})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RST 38H
+	cpu.pc = register16(0x03)
+	cpu.sp = register16(0xFFFE)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.pc != Word(0x0038) {
+		t.Error()
+	}
+	if cpu.sp != Word(0xFFFC) {
+		t.Error()
+	}
+	if cpu.readWord(0xFFFC) != Word(0x0004) {
+		t.Error()
+	}
+}
+
+func TestOpCB00(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x00})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RLC B -- NZ, C
+	cpu.b.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x0B) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RLC B -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.b.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB01(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x01})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RLC C -- NZ, C
+	cpu.c.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x0B) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RLC C -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.c.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB02(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x02})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RLC D -- NZ, C
+	cpu.d.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x0B) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RLC D -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.d.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB03(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x03})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RLC E -- NZ, C
+	cpu.e.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x0B) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RLC E -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.e.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB04(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x04})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RLC H -- NZ, C
+	cpu.h.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x0B) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RLC H -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB05(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x05})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RLC L -- NZ, C
+	cpu.l.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x0B) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RLC L -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.l.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB06(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x06})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RLC (HL) -- NZ, C
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x0B) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RLC (HL) -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB07(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x07})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RLC A -- NZ, C
+	cpu.a.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x0B) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RLC A -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB08(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x08})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RRC B -- NZ, C
+	cpu.b.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RRC B -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.b.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB09(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x09})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RRC C -- NZ, C
+	cpu.c.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RRC C -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.c.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB0A(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x0A})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RRC D -- NZ, C
+	cpu.d.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RRC D -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.d.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB0B(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x0B})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RRC E -- NZ, C
+	cpu.e.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RRC E -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.e.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB0C(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x0C})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RRC H -- NZ, C
+	cpu.h.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RRC H -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB0D(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x0D})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RRC L -- NZ, C
+	cpu.l.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RRC L -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.l.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB0E(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x0E})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RRC (HL) -- NZ, C
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RRC (HL) -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB0F(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x0F})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RRC A -- NZ, C
+	cpu.a.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RRC A -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB10(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x10})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RL B -- no carry, NZ, C
+	cpu.b.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x0A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RL B -- carry, NZ, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.b.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x0B) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RL B -- no carry, Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.b.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB12(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x12})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RL D -- no carry, NZ, C
+	cpu.d.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x0A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RL D -- carry, NZ, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.d.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x0B) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RL D -- no carry, Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.d.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB13(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x13})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RL E -- no carry, NZ, C
+	cpu.e.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x0A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RL E -- carry, NZ, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.e.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x0B) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RL E -- no carry, Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.e.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB14(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x14})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RL H -- no carry, NZ, C
+	cpu.h.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x0A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RL H -- carry, NZ, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.h.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x0B) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RL H -- no carry, Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB15(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x15})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RL L -- no carry, NZ, C
+	cpu.l.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x0A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RL L -- carry, NZ, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.l.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x0B) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RL L -- no carry, Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.l.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB16(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x16})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RL (HL) -- no carry, NZ, C
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x0A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RL (HL) -- carry, NZ, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x0B) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RL (HL) -- no carry, Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB17(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x17})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RL A -- no carry, NZ, C
+	cpu.a.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x0A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RL A -- carry, NZ, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x0B) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RL A -- no carry, Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB18(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x18})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RR B -- no carry, NZ, C
+	cpu.b.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x42) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RR B -- carry, NZ, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.b.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RR B -- no carry, Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.b.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB19(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x19})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RR C -- no carry, NZ, C
+	cpu.c.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x42) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RR C -- carry, NZ, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.c.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RR C -- no carry, Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.c.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB1A(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x1A})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RR D -- no carry, NZ, C
+	cpu.d.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x42) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RR D -- carry, NZ, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.d.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RR D -- no carry, Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.d.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB1B(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x1B})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RR E -- no carry, NZ, C
+	cpu.e.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x42) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RR E -- carry, NZ, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.e.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RR E -- no carry, Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.e.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB1C(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x1C})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RR H -- no carry, NZ, C
+	cpu.h.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x42) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RR H -- carry, NZ, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.h.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RR H -- no carry, Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB1D(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x1D})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RR L -- no carry, NZ, C
+	cpu.l.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x42) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RR L -- carry, NZ, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.l.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RR L -- no carry, Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.l.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB1E(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x1E})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RR (HL) -- no carry, NZ, C
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x42) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RR (HL) -- carry, NZ, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RR (HL) -- no carry, Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB1F(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x1F})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RR A -- no carry, NZ, C
+	cpu.a.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x42) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RR A -- carry, NZ, C
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// RR A -- no carry, Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB20(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x20})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SLA B -- NZ, C
+	cpu.b.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x0A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SLA B -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.b.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB21(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x21})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SLA C -- NZ, C
+	cpu.c.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x0A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SLA C -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.c.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB22(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x22})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SLA D -- NZ, C
+	cpu.d.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x0A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SLA D -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.d.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB23(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x23})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SLA E -- NZ, C
+	cpu.e.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x0A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SLA E -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.e.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB24(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x24})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SLA H -- NZ, C
+	cpu.h.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x0A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SLA H -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB25(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x25})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SLA L -- NZ, C
+	cpu.l.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x0A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SLA L -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.l.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB26(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x26})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SLA (HL) -- NZ, C
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x0A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SLA (HL) -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB27(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x27})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SLA A -- NZ, C
+	cpu.a.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x0A) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SLA A -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB28(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x28})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SRA B -- NZ, C
+	cpu.b.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SRA B -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.b.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB29(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x29})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SRA C -- NZ, C
+	cpu.c.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SRA C -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.c.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB2A(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x2A})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SRA D -- NZ, C
+	cpu.d.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SRA D -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.d.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB2B(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x2B})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SRA E -- NZ, C
+	cpu.e.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SRA E -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.e.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB2C(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x2C})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SRA H -- NZ, C
+	cpu.h.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SRA H -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB2D(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x2D})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SRA L -- NZ, C
+	cpu.l.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SRA L -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.l.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB2E(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x2E})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SRA (HL) -- NZ, C
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SRA (HL) -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB2F(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x2F})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SRA A -- NZ, C
+	cpu.a.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xC2) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SRA A -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB30(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x30})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SWAP B -- NZ, NC
+	cpu.b.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x58) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SWAP B -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.b.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB31(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x31})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SWAP C -- NZ, NC
+	cpu.c.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x58) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SWAP C -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.c.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB32(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x32})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SWAP D -- NZ, NC
+	cpu.d.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x58) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SWAP D -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.d.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB33(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x33})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SWAP E -- NZ, NC
+	cpu.e.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x58) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SWAP E -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.e.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB34(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x34})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SWAP H -- NZ, NC
+	cpu.h.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x58) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SWAP H -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB35(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x35})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SWAP L -- NZ, NC
+	cpu.l.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x58) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SWAP L -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.l.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB36(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x36})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SWAP (HL) -- NZ, NC
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x58) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SWAP (HL) -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB37(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x37})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SWAP A -- NZ, NC
+	cpu.a.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x58) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+
+	// SWAP A -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB38(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x38})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SRL B -- NZ, C
+	cpu.b.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x42) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SRL B -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.b.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB39(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x39})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SRL C -- NZ, C
+	cpu.c.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x42) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SRL C -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.c.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB3A(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x3A})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SRL D -- NZ, C
+	cpu.d.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x42) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SRL D -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.d.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB3B(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x3B})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SRL E -- NZ, C
+	cpu.e.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x42) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SRL E -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.e.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB3C(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x3C})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SRL H -- NZ, C
+	cpu.h.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x42) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SRL H -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB3D(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x3D})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SRL L -- NZ, C
+	cpu.l.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x42) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SRL L -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.l.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB3E(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x3E})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SRL (HL) -- NZ, C
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x42) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SRL (HL) -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB3F(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x3F})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SRL A -- NZ, C
+	cpu.a.set(0x85)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x42) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// SRL A -- Z, NC
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.a.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != false {
+		t.Error()
+	}
+}
+
+func TestOpCB40(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x40})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 0, B -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.b.set(0x01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 0, B -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.b.set(0xFE)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB41(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x41})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 0, C -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.c.set(0x01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 0, C -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.c.set(0xFE)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB42(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x42})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 0, D -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.d.set(0x01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 0, D -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.d.set(0xFE)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB43(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x43})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 0, E -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.e.set(0x01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 0, E -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.e.set(0xFE)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB44(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x44})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 0, H -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.h.set(0x01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 0, H -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.h.set(0xFE)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB45(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x45})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 0, L -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.l.set(0x01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 0, L -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.l.set(0xFE)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB46(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x46})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 0, (HL) -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 0, (HL) -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0xFE)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB47(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x47})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 0, A -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x01)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 0, A -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0xFE)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB48(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x48})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 1, B -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.b.set(0x02)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 1, B -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.b.set(0xFD)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB49(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x49})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 1, C -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.c.set(0x02)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 1, C -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.c.set(0xFD)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB4A(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x4A})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 1, D -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.d.set(0x02)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 1, D -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.d.set(0xFD)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB4B(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x4B})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 1, E -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.e.set(0x02)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 1, E -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.e.set(0xFD)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB4C(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x4C})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 1, H -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.h.set(0x02)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 1, H -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.h.set(0xFD)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB4D(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x4D})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 1, L -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.l.set(0x02)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 1, L -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.l.set(0xFD)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB4E(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x4E})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 1, (HL) -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x02)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 1, (HL) -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0xFD)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB4F(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x4F})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 1, A -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x02)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 1, A -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0xFD)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB50(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x50})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 2, B -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.b.set(0x04)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 2, B -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.b.set(0xFB)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB51(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x51})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 2, C -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.c.set(0x04)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 2, C -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.c.set(0xFB)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB52(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x52})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 2, D -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.d.set(0x04)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 2, D -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.d.set(0xFB)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB53(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x53})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 2, E -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.e.set(0x04)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 2, E -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.e.set(0xFB)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB54(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x54})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 2, H -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.h.set(0x04)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 2, H -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.h.set(0xFB)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB55(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x55})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 2, L -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.l.set(0x04)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 2, L -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.l.set(0xFB)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB56(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x56})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 2, (HL) -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x04)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 2, (HL) -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0xFB)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB57(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x57})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 2, A -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x04)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 2, A -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0xFB)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB58(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x58})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 3, B -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.b.set(0x08)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 3, B -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.b.set(0xF7)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB59(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x59})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 3, C -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.c.set(0x08)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 3, C -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.c.set(0xF7)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB5A(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x5A})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 3, D -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.d.set(0x08)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 3, D -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.d.set(0xF7)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB5B(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x5B})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 3, E -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.e.set(0x08)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 3, E -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.e.set(0xF7)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB5C(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x5C})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 3, H -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.h.set(0x08)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 3, H -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.h.set(0xF7)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB5D(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x5D})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 3, L -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.l.set(0x08)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 3, L -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.l.set(0xF7)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB5E(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x5E})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 3, (HL) -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x08)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 3, (HL) -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0xF7)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB5F(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x5F})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 3, A -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x08)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 3, A -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0xF7)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB60(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x60})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 4, B -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.b.set(0x10)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 4, B -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.b.set(0xEF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB61(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x61})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 4, C -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.c.set(0x10)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 4, C -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.c.set(0xEF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB62(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x62})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 4, D -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.d.set(0x10)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 4, D -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.d.set(0xEF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB63(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x63})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 4, E -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.e.set(0x10)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 4, E -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.e.set(0xEF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB64(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x64})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 4, H -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.h.set(0x10)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 4, H -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.h.set(0xEF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB65(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x65})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 4, L -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.l.set(0x10)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 4, L -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.l.set(0xEF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB66(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x66})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 4, (HL) -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x10)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 4, (HL) -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0xEF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB67(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x67})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 4, A -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x10)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 4, A -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0xEF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB68(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x68})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 5, B -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.b.set(0x20)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 5, B -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.b.set(0xDF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB69(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x69})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 5, C -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.c.set(0x20)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 5, C -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.c.set(0xDF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB6A(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x6A})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 5, D -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.d.set(0x20)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 5, D -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.d.set(0xDF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB6B(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x6B})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 5, E -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.e.set(0x20)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 5, E -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.e.set(0xDF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB6C(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x6C})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 5, H -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.h.set(0x20)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 5, H -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.h.set(0xDF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB6D(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x6D})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 5, L -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.l.set(0x20)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 5, L -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.l.set(0xDF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB6E(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x6E})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 5, (HL) -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x20)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 5, (HL) -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0xDF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB6F(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x6F})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 5, A -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x20)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 5, A -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0xDF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB70(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x70})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 6, B -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.b.set(0x40)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 6, B -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.b.set(0xBF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB71(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x71})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 6, C -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.c.set(0x40)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 6, C -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.c.set(0xBF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB72(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x72})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 6, D -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.d.set(0x40)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 6, D -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.d.set(0xBF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB73(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x73})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 6, E -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.e.set(0x40)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 6, E -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.e.set(0xBF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB74(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x74})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 6, H -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.h.set(0x40)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 6, H -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.h.set(0xBF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB75(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x75})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 6, L -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.l.set(0x40)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 6, L -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.l.set(0xBF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB76(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x76})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 6, (HL) -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x40)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 6, (HL) -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0xBF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB77(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x77})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 6, A -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x40)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 6, A -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0xBF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB78(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x78})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 7, B -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.b.set(0x80)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 7, B -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.b.set(0x7F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB79(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x79})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 7, C -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.c.set(0x80)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 7, C -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.c.set(0x7F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB7A(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x7A})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 7, D -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.d.set(0x80)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 7, D -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.d.set(0x7F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB7B(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x7B})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 7, E -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.e.set(0x80)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 7, E -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.e.set(0x7F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB7D(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x7D})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 7, L -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.l.set(0x80)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 7, L -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.l.set(0x7F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB7E(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x7E})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 7, (HL) -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x80)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 7, (HL) -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x7F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB7F(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x7F})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// BIT 7, A -- NZ, C unaffected
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x80)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+
+	// BIT 7, A -- Z, C unaffected
+	cpu.pc = 0
+	cpu.f.reset()
+	cpu.f.setFlag(flagC)
+	cpu.a.set(0x7F)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagN) != false {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagH) != true {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagC) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB80(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x80})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 0, B
+	cpu.f.setFlag(flagZ)
+	cpu.b.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0xFE) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB81(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x81})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 0, C
+	cpu.f.setFlag(flagZ)
+	cpu.c.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0xFE) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB82(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x82})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 0, D
+	cpu.f.setFlag(flagZ)
+	cpu.d.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0xFE) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB83(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x83})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 0, E
+	cpu.f.setFlag(flagZ)
+	cpu.e.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0xFE) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB84(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x84})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 0, H
+	cpu.f.setFlag(flagZ)
+	cpu.h.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0xFE) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB85(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x85})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 0, L
+	cpu.f.setFlag(flagZ)
+	cpu.l.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0xFE) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB86(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x86})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 0, (HL)
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0xFE) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB88(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x88})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 1, B
+	cpu.f.setFlag(flagZ)
+	cpu.b.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0xFD) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB89(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x89})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 1, C
+	cpu.f.setFlag(flagZ)
+	cpu.c.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0xFD) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB8A(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x8A})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 1, D
+	cpu.f.setFlag(flagZ)
+	cpu.d.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0xFD) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB8B(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x8B})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 1, E
+	cpu.f.setFlag(flagZ)
+	cpu.e.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0xFD) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB8C(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x8C})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 1, H
+	cpu.f.setFlag(flagZ)
+	cpu.h.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0xFD) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB8D(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x8D})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 1, L
+	cpu.f.setFlag(flagZ)
+	cpu.l.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0xFD) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB8E(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x8E})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 1, (HL)
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0xFD) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB8F(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x8F})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 1, A
+	cpu.f.setFlag(flagZ)
+	cpu.a.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xFD) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB90(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x90})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 2, B
+	cpu.f.setFlag(flagZ)
+	cpu.b.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0xFB) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB91(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x91})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 2, C
+	cpu.f.setFlag(flagZ)
+	cpu.c.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0xFB) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB92(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x92})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 2, D
+	cpu.f.setFlag(flagZ)
+	cpu.d.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0xFB) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB93(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x93})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 2, E
+	cpu.f.setFlag(flagZ)
+	cpu.e.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0xFB) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB94(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x94})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 2, H
+	cpu.f.setFlag(flagZ)
+	cpu.h.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0xFB) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB95(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x95})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 2, L
+	cpu.f.setFlag(flagZ)
+	cpu.l.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0xFB) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB96(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x96})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 2, (HL)
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0xFB) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB97(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x97})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 2, A
+	cpu.f.setFlag(flagZ)
+	cpu.a.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xFB) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB98(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x98})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 3, B
+	cpu.f.setFlag(flagZ)
+	cpu.b.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0xF7) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB99(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x99})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 3, C
+	cpu.f.setFlag(flagZ)
+	cpu.c.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0xF7) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB9A(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x9A})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 3, D
+	cpu.f.setFlag(flagZ)
+	cpu.d.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0xF7) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB9B(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x9B})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 3, E
+	cpu.f.setFlag(flagZ)
+	cpu.e.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0xF7) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB9C(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x9C})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 3, H
+	cpu.f.setFlag(flagZ)
+	cpu.h.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0xF7) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB9D(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x9D})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 3, L
+	cpu.f.setFlag(flagZ)
+	cpu.l.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0xF7) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB9E(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x9E})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 3, (HL)
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0xF7) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCB9F(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x9F})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 3, A
+	cpu.f.setFlag(flagZ)
+	cpu.a.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xF7) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBA0(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xA0})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 4, B
+	cpu.f.setFlag(flagZ)
+	cpu.b.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0xEF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBA1(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xA1})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 4, C
+	cpu.f.setFlag(flagZ)
+	cpu.c.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0xEF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBA2(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xA2})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 4, D
+	cpu.f.setFlag(flagZ)
+	cpu.d.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0xEF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBA3(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xA3})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 4, E
+	cpu.f.setFlag(flagZ)
+	cpu.e.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0xEF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBA4(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xA4})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 4, H
+	cpu.f.setFlag(flagZ)
+	cpu.h.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0xEF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBA5(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xA5})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 4, L
+	cpu.f.setFlag(flagZ)
+	cpu.l.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0xEF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBA6(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xA6})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 4, (HL)
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0xEF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBA7(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xA7})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 4, A
+	cpu.f.setFlag(flagZ)
+	cpu.a.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xEF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBA8(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xA8})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 5, B
+	cpu.f.setFlag(flagZ)
+	cpu.b.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0xDF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBA9(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xA9})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 5, C
+	cpu.f.setFlag(flagZ)
+	cpu.c.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0xDF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBAA(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xAA})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 5, D
+	cpu.f.setFlag(flagZ)
+	cpu.d.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0xDF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBAB(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xAB})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 5, E
+	cpu.f.setFlag(flagZ)
+	cpu.e.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0xDF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBAC(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xAC})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 5, H
+	cpu.f.setFlag(flagZ)
+	cpu.h.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0xDF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBAD(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xAD})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 5, L
+	cpu.f.setFlag(flagZ)
+	cpu.l.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0xDF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBAE(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xAE})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 5, (HL)
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0xDF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBAF(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xAF})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 5, A
+	cpu.f.setFlag(flagZ)
+	cpu.a.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xDF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBB0(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xB0})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 6, B
+	cpu.f.setFlag(flagZ)
+	cpu.b.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0xBF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBB1(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xB1})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 6, C
+	cpu.f.setFlag(flagZ)
+	cpu.c.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0xBF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBB2(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xB2})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 6, D
+	cpu.f.setFlag(flagZ)
+	cpu.d.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0xBF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBB3(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xB3})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 6, E
+	cpu.f.setFlag(flagZ)
+	cpu.e.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0xBF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBB4(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xB4})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 6, H
+	cpu.f.setFlag(flagZ)
+	cpu.h.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0xBF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBB5(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xB5})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 6, L
+	cpu.f.setFlag(flagZ)
+	cpu.l.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0xBF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBB6(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xB6})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 6, (HL)
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0xBF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBB7(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xB7})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 6, A
+	cpu.f.setFlag(flagZ)
+	cpu.a.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0xBF) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBB8(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xB8})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 7, B
+	cpu.f.setFlag(flagZ)
+	cpu.b.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x7F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBB9(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xB9})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 7, C
+	cpu.f.setFlag(flagZ)
+	cpu.c.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x7F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBBA(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xBA})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 7, D
+	cpu.f.setFlag(flagZ)
+	cpu.d.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x7F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBBB(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xBB})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 7, E
+	cpu.f.setFlag(flagZ)
+	cpu.e.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x7F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBBC(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xBC})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 7, H
+	cpu.f.setFlag(flagZ)
+	cpu.h.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x7F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBBD(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xBD})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 7, L
+	cpu.f.setFlag(flagZ)
+	cpu.l.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x7F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBBE(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xBE})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 7, (HL)
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x7F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBBF(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xBF})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// RES 7, A
+	cpu.f.setFlag(flagZ)
+	cpu.a.set(0xFF)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x7F) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBC0(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xC0})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 0, B
+	cpu.f.setFlag(flagZ)
+	cpu.b.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x01) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBC1(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xC1})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 0, C
+	cpu.f.setFlag(flagZ)
+	cpu.c.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x01) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBC2(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xC2})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 0, D
+	cpu.f.setFlag(flagZ)
+	cpu.d.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x01) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBC3(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xC3})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 0, E
+	cpu.f.setFlag(flagZ)
+	cpu.e.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x01) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBC4(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xC4})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 0, H
+	cpu.f.setFlag(flagZ)
+	cpu.h.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x01) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBC5(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xC5})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 0, L
+	cpu.f.setFlag(flagZ)
+	cpu.l.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x01) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBC6(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xC6})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 0, (HL)
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x01) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBC7(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xC7})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 0, A
+	cpu.f.setFlag(flagZ)
+	cpu.a.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x01) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBC8(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xC8})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 1, B
+	cpu.f.setFlag(flagZ)
+	cpu.b.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x02) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBC9(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xC9})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 1, C
+	cpu.f.setFlag(flagZ)
+	cpu.c.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x02) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBCA(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xCA})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 1, D
+	cpu.f.setFlag(flagZ)
+	cpu.d.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x02) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBCB(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xCB})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 1, E
+	cpu.f.setFlag(flagZ)
+	cpu.e.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x02) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBCC(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xCC})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 1, H
+	cpu.f.setFlag(flagZ)
+	cpu.h.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x02) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBCD(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xCD})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 1, L
+	cpu.f.setFlag(flagZ)
+	cpu.l.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x02) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBCE(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xCE})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 1, (HL)
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x02) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBCF(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xCF})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 1, A
+	cpu.f.setFlag(flagZ)
+	cpu.a.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x02) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBD0(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xD0})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 2, B
+	cpu.f.setFlag(flagZ)
+	cpu.b.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x04) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBD1(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xD1})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 2, C
+	cpu.f.setFlag(flagZ)
+	cpu.c.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x04) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBD2(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xD2})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 2, D
+	cpu.f.setFlag(flagZ)
+	cpu.d.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x04) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBD3(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xD3})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 2, E
+	cpu.f.setFlag(flagZ)
+	cpu.e.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x04) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBD4(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xD4})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 2, H
+	cpu.f.setFlag(flagZ)
+	cpu.h.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x04) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBD5(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xD5})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 2, L
+	cpu.f.setFlag(flagZ)
+	cpu.l.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x04) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBD6(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xD6})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 2, (HL)
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x04) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBD7(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xD7})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 2, A
+	cpu.f.setFlag(flagZ)
+	cpu.a.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x04) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBD8(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xD8})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 3, B
+	cpu.f.setFlag(flagZ)
+	cpu.b.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x08) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBD9(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xD9})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 3, C
+	cpu.f.setFlag(flagZ)
+	cpu.c.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x08) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBDA(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xDA})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 3, D
+	cpu.f.setFlag(flagZ)
+	cpu.d.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x08) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBDB(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xDB})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 3, E
+	cpu.f.setFlag(flagZ)
+	cpu.e.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x08) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBDC(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xDC})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 3, H
+	cpu.f.setFlag(flagZ)
+	cpu.h.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x08) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBDD(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xDD})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 3, L
+	cpu.f.setFlag(flagZ)
+	cpu.l.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x08) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBDE(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xDE})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 3, (HL)
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x08) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBDF(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xDF})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 3, A
+	cpu.f.setFlag(flagZ)
+	cpu.a.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x08) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBE0(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xE0})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 4, B
+	cpu.f.setFlag(flagZ)
+	cpu.b.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBE1(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xE1})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 4, C
+	cpu.f.setFlag(flagZ)
+	cpu.c.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBE2(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xE2})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 4, D
+	cpu.f.setFlag(flagZ)
+	cpu.d.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBE3(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xE3})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 4, E
+	cpu.f.setFlag(flagZ)
+	cpu.e.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBE4(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xE4})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 4, H
+	cpu.f.setFlag(flagZ)
+	cpu.h.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBE5(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xE5})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 4, L
+	cpu.f.setFlag(flagZ)
+	cpu.l.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBE6(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xE6})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 4, (HL)
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBE7(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xE7})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 4, A
+	cpu.f.setFlag(flagZ)
+	cpu.a.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x10) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBE8(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xE8})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 5, B
+	cpu.f.setFlag(flagZ)
+	cpu.b.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x20) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBE9(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xE9})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 5, C
+	cpu.f.setFlag(flagZ)
+	cpu.c.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x20) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBEA(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xEA})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 5, D
+	cpu.f.setFlag(flagZ)
+	cpu.d.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x20) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBEB(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xEB})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 5, E
+	cpu.f.setFlag(flagZ)
+	cpu.e.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x20) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBEC(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xEC})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 5, H
+	cpu.f.setFlag(flagZ)
+	cpu.h.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x20) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBED(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xED})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 5, L
+	cpu.f.setFlag(flagZ)
+	cpu.l.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x20) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBEE(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xEE})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 5, (HL)
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x20) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBEF(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xEF})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 5, A
+	cpu.f.setFlag(flagZ)
+	cpu.a.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x20) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBF0(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xF0})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 6, B
+	cpu.f.setFlag(flagZ)
+	cpu.b.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x40) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBF1(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xF1})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 6, C
+	cpu.f.setFlag(flagZ)
+	cpu.c.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x40) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBF2(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xF2})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 6, D
+	cpu.f.setFlag(flagZ)
+	cpu.d.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x40) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBF3(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xF3})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 6, E
+	cpu.f.setFlag(flagZ)
+	cpu.e.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x40) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBF4(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xF4})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 6, H
+	cpu.f.setFlag(flagZ)
+	cpu.h.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x40) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBF5(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xF5})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 6, L
+	cpu.f.setFlag(flagZ)
+	cpu.l.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x40) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBF6(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xF6})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 6, (HL)
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x40) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBF7(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xF7})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 6, A
+	cpu.f.setFlag(flagZ)
+	cpu.a.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x40) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBF8(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xF8})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 7, B
+	cpu.f.setFlag(flagZ)
+	cpu.b.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.b.Byte() != Byte(0x80) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBF9(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xF9})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 7, C
+	cpu.f.setFlag(flagZ)
+	cpu.c.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.c.Byte() != Byte(0x80) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBFA(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xFA})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 7, D
+	cpu.f.setFlag(flagZ)
+	cpu.d.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.d.Byte() != Byte(0x80) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBFB(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xFB})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 7, E
+	cpu.f.setFlag(flagZ)
+	cpu.e.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.e.Byte() != Byte(0x80) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBFC(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xFC})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 7, H
+	cpu.f.setFlag(flagZ)
+	cpu.h.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.h.Byte() != Byte(0x80) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBFD(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xFD})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 7, L
+	cpu.f.setFlag(flagZ)
+	cpu.l.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.l.Byte() != Byte(0x80) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBFE(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xFE})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 7, (HL)
+	cpu.f.setFlag(flagZ)
+	cpu.h.setWord(0xFF80)
+	cpu.writeByte(0xFF80, 0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.readByte(0xFF80) != Byte(0x80) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestOpCBFF(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0xFF})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// SET 7, A
+	cpu.f.setFlag(flagZ)
+	cpu.a.set(0x00)
+	cpu.fetch()
+	cpu.execute()
+	if cpu.a.Byte() != Byte(0x80) {
+		t.Error()
+	}
+	if cpu.f.getFlag(flagZ) != true {
+		t.Error()
+	}
+}
+
+func TestBranchCycles(t *testing.T) {
+	branches := []struct {
+		op       Byte
+		flag     Byte
+		set      bool
+		notTaken ClockType
+		taken    ClockType
+	}{
+		{0x20, flagZ, false, 8, 12},  // JR NZ
+		{0x28, flagZ, true, 8, 12},   // JR Z
+		{0x30, flagC, false, 8, 12},  // JR NC
+		{0x38, flagC, true, 8, 12},   // JR C
+		{0xC0, flagZ, false, 8, 20},  // RET NZ
+		{0xC8, flagZ, true, 8, 20},   // RET Z
+		{0xD0, flagC, false, 8, 20},  // RET NC
+		{0xD8, flagC, true, 8, 20},   // RET C
+		{0xC2, flagZ, false, 12, 16}, // JP NZ
+		{0xCA, flagZ, true, 12, 16},  // JP Z
+		{0xD2, flagC, false, 12, 16}, // JP NC
+		{0xDA, flagC, true, 12, 16},  // JP C
+		{0xC4, flagZ, false, 12, 24}, // CALL NZ
+		{0xCC, flagZ, true, 12, 24},  // CALL Z
+		{0xD4, flagC, false, 12, 24}, // CALL NC
+		{0xDC, flagC, true, 12, 24},  // CALL C
+	}
+	for _, b := range branches {
+		cpu := NewCpu(newTestMmu(), []Byte{b.op, 0x00, 0x00})
+
+		// not taken
+		cpu.sp = register16(0xFFFC)
+		if b.set {
+			cpu.f.resetFlag(b.flag)
+		} else {
+			cpu.f.setFlag(b.flag)
+		}
+		cpu.fetch()
+		cpu.execute()
+		if cpu.t != b.notTaken {
+			t.Errorf("0x%02X not taken: %d", b.op, cpu.t)
+		}
+
+		// taken
+		cpu.pc = 0
+		cpu.t = 0
+		if b.set {
+			cpu.f.setFlag(b.flag)
+		} else {
+			cpu.f.resetFlag(b.flag)
+		}
+		cpu.fetch()
+		cpu.execute()
+		if cpu.t != b.taken {
+			t.Errorf("0x%02X taken: %d", b.op, cpu.t)
+		}
+		cpu.RunCommand(CmdStop, nil)
+	}
+}
+
 /*
 func TestBit(t *testing.T) {
 	cpu := NewCpu(newTestMmu(), []Byte{0xCB, 0x7C, 0xCB, 0x7C})
@@ -27,25 +27,57 @@ type Cpu struct {
 	clock *Clock
 	m     ClockType // machine cycles
 	t     ClockType // clock cycles
-	div   Word
+	timer timer     // DIV, TIMA, TMA and TAC
+	dma   dma       // oam dma started through FF46
+
+	// clock cycles since power on
+	cycles uint64
+
+	// clock cycles the current instruction reports on top of its base cost,
+	// used by conditional instructions when the branch is taken
+	tBranch ClockType
+
+	// with cycleAccurate every memory access advances the clock by one
+	// machine cycle when it happens, tBus counts the cycles already sent
+	cycleAccurate bool
+	tBus          ClockType
 
 	// current instruction buffer
 	inst instruction
 
 	// interrupt master enable
-	ime            Bit
-	imeDisableNext uint8
+	ime           Bit
+	imeEnableNext uint8 // countdown to setting ime after EI
+
+	// low power states
+	halted  bool
+	haltBug bool // next fetch does not increment pc
+	stopped bool
+
+	// hung on an illegal opcode, only a reset recovers
+	locked bool
+
+	// cgb speed switch, a dmg has neither KEY1 nor double speed
+	cgb         bool
+	key1        Byte
+	doubleSpeed bool
+
+	// LY always reads 0x90, lets traces match emulators without a ppu
+	stubLY bool
 
 	mmu     Mmu
 	mmuKeys AddressKeys
 
+	// cartridge ram to save, nil without a battery
+	battery BatteryRam
+
 	// internal state
 	bios         []Byte
 	biosFinished bool
-	tima         timer
 
 	// notifications
-	notifyInst []chan string
+	tracers      []*Tracer
+	notifyLockup []chan LockupEvent
 
 	// cpu information
 	hz     float64
@@ -54,6 +86,10 @@ type Cpu struct {
 
 // NewCpu creates a new Cpu with mmu connection.
 func NewCpu(mmu Mmu, bios []Byte) *Cpu {
+	return newCpu(mmu, bios, NewCommander("cpu"))
+}
+
+func newCpu(mmu Mmu, bios []Byte, commander *Commander) *Cpu {
 	// use internal clock
 	// 1 machine cycle = 4 clock cycles
 	// machine cycles: 1.05MHz nop: 1 cycle
@@ -80,19 +116,16 @@ func NewCpu(mmu Mmu, bios []Byte) *Cpu {
 
 	mmuKeys := AddressKeys(0)
 	mmuKeys = mmu.LockAddr(AddrRom, mmuKeys)
+	mmuKeys = mmu.LockAddr(AddrERam, mmuKeys)
 	mmuKeys = mmu.LockAddr(AddrRam, mmuKeys)
 	mmuKeys = mmu.LockAddr(AddrIF, mmuKeys)
-	mmuKeys = mmu.LockAddr(AddrDIV, mmuKeys)
-	mmuKeys = mmu.LockAddr(AddrTIMA, mmuKeys)
-	mmuKeys = mmu.LockAddr(AddrTMA, mmuKeys)
-	mmuKeys = mmu.LockAddr(AddrTAC, mmuKeys)
 	mmuKeys = mmu.LockAddr(AddrZero, mmuKeys)
 	mmuKeys = mmu.LockAddr(AddrIE, mmuKeys)
 
-	commander := NewCommander("cpu")
 	cpu := &Cpu{CommanderInterface: commander,
 		a: a, b: b, c: c, d: d, e: e, f: f, l: l, h: h,
 		clock:        NewClock(),
+		dma:          dma{reg: 0xFF},
 		ime:          Bit(1),
 		mmu:          mmu,
 		mmuKeys:      mmuKeys,
@@ -104,6 +137,10 @@ func NewCpu(mmu Mmu, bios []Byte) *Cpu {
 		CmdClockAccumulator: cpu.cmdClock,
 		CmdString:           cpu.cmdString,
 		CmdOnInstruction:    cpu.cmdOnInstruction,
+		CmdUnloadBios:       cpu.cmdUnloadBios,
+		CmdGetRegisters:     cpu.cmdGetRegisters,
+		CmdSetRegisters:     cpu.cmdSetRegisters,
+		CmdGetBatteryRam:    cpu.cmdGetBatteryRam,
 	}
 
 	commander.start(cpu.step, cmdHandlers, nil)
@@ -115,13 +152,43 @@ func (c *Cpu) AttachClock() chan ClockType {
 	return c.clock.Attach()
 }
 
-// return a channel that will get every instruction
-func (c *Cpu) AttachInstructions() chan string {
-	// TODO: use a construct like AttachClock
-	inst := make(chan string)
-	c.notifyInst = append(c.notifyInst, inst)
-	return inst
+// return a Tracer that gets a record of every instruction passing the
+// filters, records are dropped when more than size are waiting
+func (c *Cpu) AttachTrace(size int, filters ...TraceFilter) *Tracer {
+	t := &Tracer{C: make(chan TraceRecord, size), filters: filters}
+	c.tracers = append(c.tracers, t)
+	return t
+}
+
+// A LockupEvent reports the cpu hanging on an illegal opcode.
+type LockupEvent struct {
+	PC     Word // address of the illegal opcode
+	Opcode Byte
+}
+
+func (e LockupEvent) String() string {
+	return fmt.Sprintf("cpu locked up on illegal opcode 0x%02X at 0x%04X",
+		e.Opcode, e.PC)
+}
 
+// return a channel that gets an event when the cpu locks up
+func (c *Cpu) AttachLockup() chan LockupEvent {
+	// buffered so the cpu never blocks on a lockup nobody reads
+	lockup := make(chan LockupEvent, 1)
+	c.notifyLockup = append(c.notifyLockup, lockup)
+	return lockup
+}
+
+// lockUp hangs the cpu, the gpu and timers keep running.
+func (c *Cpu) lockUp(pc Word, op Byte) {
+	c.locked = true
+	ev := LockupEvent{pc, op}
+	for _, lockup := range c.notifyLockup {
+		select {
+		case lockup <- ev:
+		default:
+		}
+	}
 }
 
 func (c *Cpu) cmdClock(resp interface{}) {
@@ -138,15 +205,32 @@ func (c *Cpu) cmdClock(resp interface{}) {
 }
 
 func (c *Cpu) cmdOnInstruction(resp interface{}) {
-	if resp, ok := resp.(chan chan string); !ok {
+	if resp, ok := resp.(chan *Tracer); !ok {
 		panic("invalid command response type")
 	} else {
-		inst := make(chan string)
-		c.notifyInst = append(c.notifyInst, inst)
-		resp <- inst
+		resp <- c.AttachTrace(1)
 	}
 }
 
+func (c *Cpu) cmdUnloadBios(resp interface{}) {
+	c.postBoot()
+}
+
+// postBoot sets up the state the dmg bios leaves behind when it hands over
+// to the cartridge at 0x0100.
+func (c *Cpu) postBoot() {
+	c.biosFinished = true
+	c.setRegisters(Registers{
+		A: 0x01, F: 0xB0, B: 0x00, C: 0x13, D: 0x00, E: 0xD8, H: 0x01, L: 0x4D,
+		SP: 0xFFFE, PC: 0x0100,
+	})
+	c.timer.counter = 0xABCC
+	c.lockAddr(AddrGpuRegs)
+	c.mmu.WriteByteAt(AddrLCDC, 0x91, c.mmuKeys)
+	c.mmu.WriteByteAt(AddrBGP, 0xFC, c.mmuKeys)
+	c.unlockAddr(AddrGpuRegs)
+}
+
 func (c *Cpu) cmdString(resp interface{}) {
 	if resp, ok := resp.(chan string); !ok {
 		panic("invalid command response type")
@@ -160,15 +244,106 @@ func (c *Cpu) str() string {
 a:%s f:%s b:%s c:%s d:%s e:%s h:%s l:%s sp:%d pc:%d
 ime:%d div:0x%04X %s`,
 		c.inst, c.a, c.f, c.b, c.c, c.d, c.e, c.h, c.l, c.sp, c.pc,
-		c.ime, c.div, c.f.flagsString())
+		c.ime, c.timer.counter, c.f.flagsString())
 }
 
 func (c *Cpu) String() string {
-	resp := make(chan string)
+	resp := make(chan string, 1)
 	c.RunCommand(CmdString, resp)
 	return <-resp
 }
 
+// Registers is a snapshot of the cpu state.
+type Registers struct {
+	A, F, B, C, D, E, H, L Byte
+	SP, PC                 Word
+	IME                    bool
+	Halted                 bool
+	Cycles                 uint64 // clock cycles since power on
+}
+
+func (c *Cpu) cmdGetRegisters(resp interface{}) {
+	if resp, ok := resp.(chan Registers); !ok {
+		panic("invalid command response type")
+	} else {
+		resp <- c.registers()
+	}
+}
+
+func (c *Cpu) cmdGetBatteryRam(resp interface{}) {
+	if resp, ok := resp.(chan []Byte); !ok {
+		panic("invalid command response type")
+	} else if c.battery == nil {
+		resp <- nil
+	} else {
+		resp <- append([]Byte{}, c.battery.Ram()...)
+	}
+}
+
+func (c *Cpu) cmdSetRegisters(resp interface{}) {
+	if r, ok := resp.(Registers); !ok {
+		panic("invalid command response type")
+	} else {
+		c.setRegisters(r)
+	}
+}
+
+func (c *Cpu) registers() Registers {
+	return Registers{
+		A: c.a.Byte(), F: c.f.Byte(),
+		B: c.b.Byte(), C: c.c.Byte(),
+		D: c.d.Byte(), E: c.e.Byte(),
+		H: c.h.Byte(), L: c.l.Byte(),
+		SP: c.sp, PC: c.pc,
+		IME:    c.ime == 1,
+		Halted: c.halted,
+		Cycles: c.cycles,
+	}
+}
+
+func (c *Cpu) setRegisters(r Registers) {
+	c.a.set(r.A)
+	c.f.set(r.F & 0xF0)
+	c.b.set(r.B)
+	c.c.set(r.C)
+	c.d.set(r.D)
+	c.e.set(r.E)
+	c.h.set(r.H)
+	c.l.set(r.L)
+	c.sp = r.SP
+	c.pc = r.PC
+	c.ime = 0
+	if r.IME {
+		c.ime = 1
+	}
+	c.imeEnableNext = 0
+	c.halted = r.Halted
+	c.haltBug = false
+	c.cycles = r.Cycles
+}
+
+// Registers returns a snapshot of the registers. It is safe to call while
+// the cpu is paused or running, the snapshot is taken between instructions.
+func (c *Cpu) Registers() Registers {
+	resp := make(chan Registers, 1)
+	c.RunCommand(CmdGetRegisters, resp)
+	return <-resp
+}
+
+// SetRegisters overwrites the registers between instructions. It is meant to
+// be used while the cpu is paused.
+func (c *Cpu) SetRegisters(r Registers) {
+	c.RunCommand(CmdSetRegisters, r)
+}
+
+// BatteryRam returns a copy of the battery backed cartridge ram taken between
+// instructions, or nil for cartridges without a battery.
+func (c *Cpu) BatteryRam() []Byte {
+	resp := make(chan []Byte, 1)
+	c.RunCommand(CmdGetBatteryRam, resp)
+	return <-resp
+}
+
 func (c *Cpu) lockAddr(addr Word) {
 	c.mmuKeys = c.mmu.LockAddr(addr, c.mmuKeys)
 }
@@ -177,10 +352,78 @@ func (c *Cpu) unlockAddr(addr Word) {
 	c.mmuKeys = c.mmu.UnlockAddr(addr, c.mmuKeys)
 }
 
+// tick advances the timers and the other components by one machine cycle
+// for a memory access in the middle of an instruction.
+func (c *Cpu) tick() {
+	if !c.cycleAccurate {
+		return
+	}
+	c.tBus += 4
+	c.timers(4)
+	c.broadcast(4)
+}
+
+// broadcast sends clock cycles to the other components. They run at normal
+// speed when the cpu is in double speed.
+func (c *Cpu) broadcast(t ClockType) {
+	if c.doubleSpeed {
+		t /= 2
+	}
+	c.clock.AddCycles(t)
+}
+
+// readIo reads a cpu owned io register for internal bookkeeping, this is not
+// a bus access and takes no cycles.
+func (c *Cpu) readIo(addr Word) Byte {
+	return c.mmu.ReadByteAt(addr, c.mmuKeys|akUnwatched)
+}
+
+// writeIo writes a cpu owned io register for internal bookkeeping.
+func (c *Cpu) writeIo(addr Word, b Byte) {
+	c.mmu.WriteByteAt(addr, b, c.mmuKeys|akUnwatched)
+}
+
 func (c *Cpu) readByte(addr Word) Byte {
+	c.tick()
+	if b, ok := c.dma.conflict(addr); ok {
+		if c.mmuKeys&akFetch != 0 {
+			c.mmu.Fetch(addr, b, c.mmuKeys)
+		}
+		return b
+	}
+	return c.peekByte(addr)
+}
+
+// peekUnwatched is peekByte for the cpu looking at memory for itself, like the
+// tracer, watchpoints and strict mode don't see it.
+func (c *Cpu) peekUnwatched(addr Word) Byte {
+	keys := c.mmuKeys
+	c.mmuKeys |= akUnwatched
+	b := c.peekByte(addr)
+	c.mmuKeys = keys
+	return b
+}
+
+// peekByte reads a byte the way the cpu sees it without taking any cycles.
+func (c *Cpu) peekByte(addr Word) Byte {
 	if !c.biosFinished && addr <= 0xFF {
 		return c.bios[addr]
 	}
+	if addr == AddrKEY1 && c.cgb {
+		return c.key1 | 0x7E // unused bits read 1
+	}
+	if addr == AddrLY && c.stubLY {
+		return 0x90
+	}
+	if AddrDIV <= addr && addr <= AddrTAC {
+		return c.timer.read(addr)
+	}
+	if addr == AddrIF {
+		return c.mmu.ReadByteAt(addr, c.mmuKeys) | 0xE0 // unused bits read 1
+	}
+	if addr == AddrDMA {
+		return c.dma.reg
+	}
 	if AddrVRam <= addr && addr <= AddrRam {
 		c.lockAddr(AddrVRam)
 		defer c.unlockAddr(AddrVRam)
@@ -190,11 +433,30 @@ func (c *Cpu) readByte(addr Word) Byte {
 	} else if AddrGpuRegs <= addr && addr <= AddrGpuRegsEnd {
 		c.lockAddr(AddrGpuRegs)
 		defer c.unlockAddr(AddrGpuRegs)
+		if addr == AddrSTAT {
+			return c.mmu.ReadByteAt(addr, c.mmuKeys) | 0x80 // unused bit reads 1
+		}
 	}
 	return c.mmu.ReadByteAt(addr, c.mmuKeys)
 }
 
 func (c *Cpu) writeByte(addr Word, b Byte) {
+	c.tick()
+	if addr == AddrKEY1 && c.cgb {
+		c.key1 = c.key1&0x80 | b&0x01 // only the switch armed bit is writable
+		return
+	}
+	if AddrDIV <= addr && addr <= AddrTAC {
+		c.timer.write(addr, b)
+		return
+	}
+	if addr == AddrDMA {
+		c.dma.start(b)
+		return
+	}
+	if _, ok := c.dma.conflict(addr); ok {
+		return
+	}
 	if AddrVRam <= addr && addr <= AddrRam {
 		c.lockAddr(AddrVRam)
 		defer c.unlockAddr(AddrVRam)
@@ -230,35 +492,72 @@ func (c *Cpu) Clock() chan ClockType {
 */
 
 func (c *Cpu) fetch() {
+	pc := c.pc
+	c.mmuKeys |= akFetch // lets watchpoints tell the opcode from data
 	op := opcode(c.readByte(c.pc))
-	c.pc++
+	c.mmuKeys &^= akFetch
+	if c.haltBug {
+		// the byte after halt is read twice
+		c.haltBug = false
+	} else {
+		c.pc++
+	}
+	var cmd *command
 	if op == 0xCB {
 		op = opcode(0xCB00 + uint16(c.readByte(c.pc)))
 		c.pc++
+		cmd = &cbCommandTable[op&0xFF]
+	} else {
+		cmd = &commandTable[op]
 	}
-	if cmd, ok := commandTable[op]; ok {
-		p := []Byte{}
-		for i := uint8(0); i < cmd.b; i++ {
-			p = append(p, c.readByte(c.pc))
-			c.pc++
-		}
-		c.inst = newInstruction(op, p...)
+	if cmd.f == nil {
+		c.t += 4 // the fetch still takes its machine cycle
+		c.lockUp(pc, Byte(op))
+		return
+	}
+	c.inst.o = op
+	c.inst.n = cmd.b
+	c.inst.cmd = cmd
+	for i := uint8(0); i < cmd.b; i++ {
+		c.inst.p[i] = c.readByte(c.pc)
+		c.pc++
+	}
+}
+
+// trace sends the fetched instruction and the registers from before the
+// fetch to the tracers.
+func (c *Cpu) trace(regs Registers) {
+	r := TraceRecord{PC: regs.PC, Bank: c.mmu.RomBank(), Registers: regs}
+	if c.inst.o > 0xFF {
+		r.Bytes[0], r.Bytes[1] = 0xCB, Byte(c.inst.o)
+		r.Len = 2
 	} else {
-		panic(op)
+		r.Bytes[0] = Byte(c.inst.o)
+		copy(r.Bytes[1:], c.inst.p[:c.inst.n])
+		r.Len = 1 + c.inst.n
+	}
+	r.Opcode = r.Bytes[0]
+	for i := range r.PCMem {
+		r.PCMem[i] = c.peekUnwatched(regs.PC + Word(i))
+	}
+	for _, t := range c.tracers {
+		t.send(&r)
 	}
 }
 
 func (c *Cpu) execute() {
-	cmd := commandTable[c.inst.o]
+	cmd := c.inst.cmd
+	c.tBranch = 0
 	cmd.f(c)
-	c.t += cmd.t
-	c.m += cmd.t * 4
-
-	// handle disabling interrupts one instruction after DI
-	if c.imeDisableNext > 0 {
-		c.imeDisableNext--
-		if c.imeDisableNext == 0 {
-			c.ime = Bit(0)
+	t := cmd.t + c.tBranch
+	c.t += t
+	c.m += t / 4
+
+	// handle enabling interrupts one instruction after EI
+	if c.imeEnableNext > 0 {
+		c.imeEnableNext--
+		if c.imeEnableNext == 0 {
+			c.ime = Bit(1)
 		}
 	}
 }
@@ -272,7 +571,7 @@ func (cpu *Cpu) setInterrupt(in Interrupt) {
 // resetInterrupt resets the specific interrupt.
 func (cpu *Cpu) resetInterrupt(i Interrupt, iflag Byte) {
 	iflag &= (Byte(i) ^ 0xFF)
-	cpu.writeByte(AddrIF, iflag)
+	cpu.writeIo(AddrIF, iflag)
 }
 
 // getInterrupt returns the highest priority enabled interrupt.
@@ -292,115 +591,129 @@ func (cpu *Cpu) getInterrupt(ie, iflag Byte) Interrupt {
 }
 
 func (cpu *Cpu) io() {
+	// latch requested interrupts, they stay pending regardless of ime and ie
+	// so a halted cpu can still wake up on them
 	iflag, _ := cpu.mmu.ReadIoByte(AddrIF, cpu.mmuKeys)
-	if cpu.ime == 0 {
-		iflag = 0 // mask all interrupts
-	} else {
-		ie := cpu.readByte(AddrIE)
-		iflag &= ie // mask interrupts
-	}
-	cpu.writeByte(AddrIF, iflag)
+	cpu.writeIo(AddrIF, iflag&0x1F)
 }
 
-func (cpu *Cpu) interrupt() {
-	if cpu.ime == 1 {
-		ie := cpu.readByte(AddrIE)
-		iflag := cpu.readByte(AddrIF)
-		in := cpu.getInterrupt(ie, iflag)
-		if in > 0 {
-			cpu.ime = 0
-			cpu.push(cpu.pc)
-			cpu.jp(in.Address())
-			cpu.resetInterrupt(in, iflag)
+// idle runs one machine cycle while halted, stopped or locked up, waking up
+// when the exit condition is met.
+func (cpu *Cpu) idle() {
+	iflag := cpu.readIo(AddrIF)
+	if cpu.stopped {
+		// only a joypad input ends stop mode
+		if iflag&Byte(InterruptKeypad) != 0 {
+			cpu.stopped = false
+		}
+	} else if cpu.halted {
+		ie := cpu.readIo(AddrIE)
+		if ie&iflag&0x1F != 0 {
+			// leaving halt takes a machine cycle before anything else runs
+			cpu.halted = false
+			cpu.tick()
+			cpu.t += 4
 		}
 	}
-}
-
-type timer struct {
-	v       Byte
-	div     uint16
-	running bool
-}
-
-func newTimer() *timer {
-	return &timer{}
-}
-
-func (t *timer) run(c ClockType, f Byte, tma Byte) (Byte, bool) {
-	overflow := false
-
-	tmaBit := uint16(1)
-	if tma == 0x00 {
-		tmaBit = 0x0400 // 10th bit
-	} else if tma == 0x01 {
-		tmaBit = 0x0010 // 4th bit
-	} else if tma == 0x02 {
-		tmaBit = 0x0040 // 6th bit
-	} else if tma == 0x03 {
-		tmaBit = 0x0100 // 8th bit
+	if cpu.halted || cpu.stopped || cpu.locked {
+		cpu.t += 4
 	}
+}
 
-	p := t.div & tmaBit
-	t.div += uint16(c)
-	if p == 0 { // previously 0
-		if t.div&tmaBit == tmaBit { // now 1
-			t.v += 1
-			if t.v == 0 {
-				overflow = true
+func (cpu *Cpu) interrupt() {
+	if cpu.ime == 1 {
+		ie := cpu.readIo(AddrIE)
+		iflag := cpu.readIo(AddrIF)
+		if cpu.getInterrupt(ie, iflag) > 0 {
+			if cpu.haltBug {
+				// EI followed by HALT, the handler returns to the HALT
+				cpu.haltBug = false
+				cpu.pc--
 			}
+			cpu.dispatch()
 		}
 	}
-
-	return t.v, overflow
 }
 
-func (t *timer) stop() {
-	t.v = 0
-	t.div = 0
-	t.running = false
-}
-
-func (cpu *Cpu) timers() {
-	// update divider
-	div := cpu.readByte(AddrDIV)
-	cpu.div = (cpu.div & 0x00FF) | (Word(div) << 8)
-	cpu.div += Word(cpu.t)
-	div = Byte(cpu.div >> 8)
-	cpu.mmu.WriteByteAt(AddrDIV, div, cpu.mmuKeys|AddressKeys(abElevated))
-
-	// update timer
-	tac := cpu.readByte(AddrTAC)
-	if tac&0x04 == 0x00 {
-		cpu.tima.stop()
-		return
+// dispatch calls the interrupt handler in 5 machine cycles: two internal
+// cycles, the pushes of the pc high and low bytes and the jump. The interrupt
+// is picked after the high byte is pushed, so a push that overwrites IE can
+// change the handler or cancel the dispatch, which then jumps to 0x0000.
+func (cpu *Cpu) dispatch() {
+	cpu.ime = 0
+	cpu.tick()
+	cpu.tick()
+	cpu.sp--
+	cpu.writeByte(cpu.sp, cpu.pc.High())
+	ie := cpu.readIo(AddrIE)
+	iflag := cpu.readIo(AddrIF)
+	in := cpu.getInterrupt(ie, iflag)
+	cpu.sp--
+	cpu.writeByte(cpu.sp, cpu.pc.Low())
+	cpu.pc = in.Address()
+	if in > 0 {
+		cpu.resetInterrupt(in, iflag)
 	}
-	tima := cpu.readByte(AddrTIMA)
-	tma := cpu.readByte(AddrTMA)
+	cpu.tick()
+	cpu.t += 20
+	cpu.m += 5
+}
 
-	tima, interrupt := cpu.tima.run(cpu.t, tac&0x03, tma)
-	if interrupt {
+// timers runs the timer and the oam dma for t clock cycles.
+func (cpu *Cpu) timers(t ClockType) {
+	if cpu.timer.run(t) {
 		cpu.setInterrupt(InterruptTimer)
 	}
-	cpu.writeByte(AddrTIMA, tima)
+	for ; t >= 4; t -= 4 {
+		if src, dst, ok := cpu.dma.step(); ok {
+			keys := cpu.mmuKeys
+			// the cpu can be fetching an opcode, the dma read is not one
+			cpu.mmuKeys = keys&^akFetch | accessorKeys(AccessDma)
+			cpu.dma.value = cpu.peekByte(src)
+			cpu.lockAddr(AddrOam)
+			cpu.mmu.WriteByteAt(dst, cpu.dma.value, cpu.mmuKeys)
+			cpu.unlockAddr(AddrOam)
+			cpu.mmuKeys = keys
+		}
+	}
 }
 
 func (c *Cpu) step(first bool, t uint32) (CommanderStateFn, bool, uint32, uint32) {
 	// reset clocks
 	c.m = 0
 	c.t = 0
+	c.tBus = 0
 	if !c.biosFinished && c.pc == 0x0100 {
 		c.biosFinished = true
 	}
-	for _, inst := range c.notifyInst {
-		inst <- c.str()
-	}
 
-	c.io()        // handle memory mapped io
-	c.interrupt() // handle interrupts
-	c.fetch()     // load next instruction into c.inst
-	c.execute()   // execute c.inst instruction
-	c.timers()    // handle tima, tma, tac
-
-	c.clock.AddCycles(c.t)
+	c.io() // handle memory mapped io
+	if c.halted || c.stopped || c.locked {
+		c.idle() // wait for an interrupt or keypad input
+	}
+	if !c.halted && !c.stopped && !c.locked {
+		c.interrupt() // handle interrupts
+		var regs Registers
+		if len(c.tracers) > 0 {
+			regs = c.registers()
+		}
+		c.fetch() // load next instruction into c.inst
+		if !c.locked {
+			if len(c.tracers) > 0 {
+				c.trace(regs)
+			}
+			c.execute() // execute c.inst instruction
+		}
+	}
+	c.cycles += uint64(c.t)
+	if c.stopped {
+		// the system clock is stopped, nothing else runs
+		return c.step, false, 0, 0
+	}
+	// run the cycles not already sent by memory accesses
+	if c.t > c.tBus {
+		c.timers(c.t - c.tBus) // handle tima, tma, tac
+		c.broadcast(c.t - c.tBus)
+	}
 	return c.step, false, 0, 0
 }
@@ -0,0 +1,302 @@
+package jibi
+
+import (
+	"testing"
+)
+
+// a small loop of loads, alu, cb and branch instructions
+var benchProgram = []Byte{
+	0x3E, 0x01, // 0x00 LD A, #
+	0x06, 0x20, // 0x02 LD B, #
+	0x80,       // 0x04 ADD A, B
+	0xCB, 0x37, // 0x05 SWAP A
+	0x05,       // 0x07 DEC B
+	0x20, 0xFA, // 0x08 JR NZ, *
+	0xC3, 0x00, 0x00, // 0x0A JP nn
+}
+
+func BenchmarkFetchExecute(b *testing.B) {
+	cpu := NewCpu(newTestMmu(), benchProgram)
+	defer cpu.RunCommand(CmdStop, nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cpu.fetch()
+		cpu.execute()
+	}
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "inst/s")
+}
+
+func BenchmarkStep(b *testing.B) {
+	cpu := NewCpu(newTestMmu(), benchProgram)
+	defer cpu.RunCommand(CmdStop, nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cpu.step(false, 0)
+	}
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "inst/s")
+}
+
+func TestCycleAccurate(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0xF0, 0x04, 0xF0, 0x04, 0xC5, 0xC0})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	// LDH A, (n) -- DIV is read after the two fetch cycles
+	cpu.timer.counter = 0x00F8
+	cpu.step(false, 0)
+	if cpu.a.Byte() != Byte(0x00) {
+		t.Error()
+	}
+	if cpu.timer.counter != Word(0x0104) {
+		t.Errorf("0x%04X", cpu.timer.counter)
+	}
+
+	// LDH A, (n) -- cycle accurate, DIV ticks before the read
+	cpu.cycleAccurate = true
+	cpu.timer.counter = 0x00F8
+	cpu.step(false, 0)
+	if cpu.a.Byte() != Byte(0x01) {
+		t.Error()
+	}
+	if cpu.timer.counter != Word(0x0104) {
+		t.Errorf("0x%04X", cpu.timer.counter)
+	}
+	if cpu.tBus != 12 || cpu.t != 12 {
+		t.Error()
+	}
+
+	// PUSH BC -- internal cycle and both writes are bus cycles
+	cpu.sp = register16(0xFFFE)
+	cpu.step(false, 0)
+	if cpu.tBus != 16 || cpu.t != 16 {
+		t.Error()
+	}
+
+	// RET NZ -- taken, the stack is read after the condition cycle. With
+	// the stack at DIV the return address shows when it was read.
+	cpu.f.set(0x00)
+	cpu.sp = register16(AddrDIV)
+	cpu.timer.counter = 0x00F4
+	cpu.step(false, 0)
+	if cpu.pc != Word(0x0001) {
+		t.Errorf("0x%04X", cpu.pc)
+	}
+	if cpu.tBus != 16 || cpu.t != 20 {
+		t.Error(cpu.tBus, cpu.t)
+	}
+}
+
+func TestLockup(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x00, 0xD3, 0x00})
+	defer cpu.RunCommand(CmdStop, nil)
+	lockup := cpu.AttachLockup()
+
+	// the fetch of the illegal opcode takes a machine cycle
+	start := cpu.timer.counter
+	cpu.step(false, 0)
+	cpu.step(false, 0)
+	if !cpu.locked {
+		t.Fatal()
+	}
+	if cpu.t != 4 || cpu.cycles != 8 || cpu.timer.counter != start+8 {
+		t.Error(cpu.t, cpu.cycles, cpu.timer.counter-start)
+	}
+	select {
+	case ev := <-lockup:
+		if ev.PC != Word(0x0001) || ev.Opcode != Byte(0xD3) {
+			t.Error(ev)
+		}
+	default:
+		t.Error("no lockup event")
+	}
+
+	// stays hung with an interrupt pending, timers keep running
+	cpu.ime = Bit(1)
+	cpu.mmu.WriteByteAt(AddrIE, 0x01, 0)
+	cpu.mmu.WriteByteAt(AddrIF, 0x01, 0)
+	div := cpu.timer.counter
+	for i := 0; i < 4; i++ {
+		cpu.step(false, 0)
+	}
+	if !cpu.locked || cpu.pc != Word(0x0002) {
+		t.Errorf("0x%04X", cpu.pc)
+	}
+	if cpu.timer.counter != div+16 {
+		t.Errorf("0x%04X", cpu.timer.counter)
+	}
+	// cycle accurate the fetch is the only bus cycle
+	cpu = NewCpu(newTestMmu(), []Byte{0xD3})
+	defer cpu.RunCommand(CmdStop, nil)
+	cpu.cycleAccurate = true
+	start = cpu.timer.counter
+	cpu.step(false, 0)
+	if cpu.tBus != 4 || cpu.t != 4 || cpu.timer.counter != start+4 {
+		t.Error(cpu.tBus, cpu.t, cpu.timer.counter-start)
+	}
+}
+
+func TestRegisters(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{0x3C, 0x00})
+	defer cpu.RunCommand(CmdStop, nil)
+
+	r := Registers{A: 0x01, F: 0xBF, B: 0x00, C: 0x13, D: 0x00, E: 0xD8,
+		H: 0x01, L: 0x4D, SP: 0xFFFE, PC: 0x0000, IME: true, Cycles: 100}
+	cpu.SetRegisters(r)
+	got := cpu.Registers()
+	r.F = 0xB0 // lower nibble reads zero
+	if got != r {
+		t.Errorf("%+v", got)
+	}
+
+	// INC A
+	cpu.step(false, 0)
+	got = cpu.registers()
+	if got.A != 0x02 || got.PC != 0x0001 || got.Cycles != 104 {
+		t.Errorf("%+v", got)
+	}
+}
+
+// newIePushCpu sets up a pending timer interrupt with pc at 0x0200 for the
+// mooneye ie_push cases.
+func newIePushCpu(sp, pc Word, ie, iflag Byte) *Cpu {
+	cpu := NewCpu(newTestMmu(), nil)
+	cpu.mmu.WriteByteAt(AddrIE, ie, 0)
+	cpu.mmu.WriteByteAt(AddrIF, iflag, 0)
+	cpu.sp = sp
+	cpu.pc = pc
+	cpu.ime = 1
+	return cpu
+}
+
+func TestIePush(t *testing.T) {
+	// the pc high byte lands in IE and disables the timer, cancelling the
+	// dispatch which then jumps to 0x0000
+	cpu := newIePushCpu(0x0000, 0x0200, 0x04, 0x04)
+	cpu.step(false, 0)
+	if cpu.pc != 0x0001 || cpu.sp != 0xFFFE {
+		t.Errorf("0x%04X 0x%04X", cpu.pc, cpu.sp)
+	}
+	if cpu.readIo(AddrIE) != 0x02 || cpu.readIo(AddrIF) != 0x04 {
+		t.Error(cpu.readIo(AddrIE), cpu.readIo(AddrIF))
+	}
+	if cpu.mmu.ReadByteAt(0xFFFE, 0) != 0x00 || cpu.t != 24 {
+		t.Error(cpu.t)
+	}
+	cpu.RunCommand(CmdStop, nil)
+
+	// the high byte keeps the timer enabled
+	cpu = newIePushCpu(0x0000, 0x0400, 0x04, 0x04)
+	cpu.step(false, 0)
+	if cpu.pc != 0x0051 || cpu.readIo(AddrIF) != 0x00 {
+		t.Errorf("0x%04X", cpu.pc)
+	}
+	cpu.RunCommand(CmdStop, nil)
+
+	// the high byte enables vblank instead, which wins
+	cpu = newIePushCpu(0x0000, 0x0150, 0x04, 0x05)
+	cpu.step(false, 0)
+	if cpu.pc != 0x0041 || cpu.readIo(AddrIF) != 0x04 {
+		t.Errorf("0x%04X", cpu.pc)
+	}
+	cpu.RunCommand(CmdStop, nil)
+
+	// the low byte lands in IE after the interrupt was picked
+	cpu = newIePushCpu(0x0001, 0x0200, 0x04, 0x04)
+	cpu.step(false, 0)
+	if cpu.pc != 0x0051 || cpu.readIo(AddrIF) != 0x00 {
+		t.Errorf("0x%04X", cpu.pc)
+	}
+	if cpu.readIo(AddrIE) != 0x00 || cpu.mmu.ReadByteAt(0x0000, 0) != 0x02 {
+		t.Error(cpu.readIo(AddrIE))
+	}
+	cpu.RunCommand(CmdStop, nil)
+}
+
+func TestInterruptTiming(t *testing.T) {
+	// dispatch and a NOP handler
+	cpu := newIePushCpu(0xFFFE, 0x0200, 0x04, 0x04)
+	cpu.step(false, 0)
+	if cpu.t != 24 || cpu.m != 6 {
+		t.Error(cpu.t, cpu.m)
+	}
+	cpu.RunCommand(CmdStop, nil)
+
+	// like mooneye intr_timing the dispatch is measured with DIV, the handler
+	// LDH A, (DIV) reads it 32 cycles after the dispatch starts
+	for _, tc := range []struct {
+		div Word
+		a   Byte
+	}{
+		{0x0100 - 32, 0x01},
+		{0x0100 - 31, 0x01},
+		{0x0100 - 33, 0x00},
+	} {
+		cpu = newIePushCpu(0xFFFE, 0x0200, 0x04, 0x04)
+		cpu.cycleAccurate = true
+		cpu.mmu.WriteByteAt(0x0050, 0xF0, 0)
+		cpu.mmu.WriteByteAt(0x0051, AddrDIV.Low(), 0)
+		cpu.timer.counter = tc.div
+		cpu.step(false, 0)
+		if cpu.a.Byte() != tc.a || cpu.t != 32 || cpu.tBus != 32 {
+			t.Error(tc.div, cpu.a, cpu.t, cpu.tBus)
+		}
+		cpu.RunCommand(CmdStop, nil)
+	}
+
+	// waking from halt takes a cycle before the dispatch
+	cpu = newIePushCpu(0xFFFE, 0x0200, 0x04, 0x00)
+	cpu.halted = true
+	cpu.step(false, 0)
+	if !cpu.halted || cpu.t != 4 {
+		t.Error(cpu.t)
+	}
+	cpu.mmu.WriteByteAt(AddrIF, 0x04, 0)
+	cpu.step(false, 0)
+	if cpu.halted || cpu.pc != 0x0051 || cpu.t != 28 {
+		t.Errorf("0x%04X %d", cpu.pc, cpu.t)
+	}
+	cpu.RunCommand(CmdStop, nil)
+
+	// with ime reset the cpu wakes and runs the next instruction
+	cpu = newIePushCpu(0xFFFE, 0x0200, 0x04, 0x04)
+	cpu.ime = 0
+	cpu.halted = true
+	cpu.step(false, 0)
+	if cpu.halted || cpu.pc != 0x0201 || cpu.t != 8 {
+		t.Errorf("0x%04X %d", cpu.pc, cpu.t)
+	}
+	cpu.RunCommand(CmdStop, nil)
+}
+
+func TestKey1Dmg(t *testing.T) {
+	j := newTestJibi([]Byte{0x10, 0x00}) // 0x0100 STOP
+	defer j.Stop()
+
+	// FF4D is open bus and STOP only stops
+	j.cpu.writeByte(AddrKEY1, 0x01)
+	if b := j.cpu.readByte(AddrKEY1); b != 0xFF {
+		t.Errorf("0x%02X", b)
+	}
+	j.Step()
+	if j.cpu.doubleSpeed || !j.cpu.stopped {
+		t.Error(j.cpu.doubleSpeed, j.cpu.stopped)
+	}
+
+	// the header cgb flag enables the switch
+	rom := make([]Byte, 0x8000)
+	copy(rom[0x0100:], []Byte{0x10, 0x00})
+	rom[0x0143] = 0x80
+	j = New(rom, Options{Skipbios: true, Synchronous: true})
+	defer j.Stop()
+	j.cpu.writeByte(AddrKEY1, 0x01)
+	if b := j.cpu.readByte(AddrKEY1); b != 0x7F {
+		t.Errorf("0x%02X", b)
+	}
+	j.Step()
+	if !j.cpu.doubleSpeed || j.cpu.stopped {
+		t.Error(j.cpu.doubleSpeed, j.cpu.stopped)
+	}
+}
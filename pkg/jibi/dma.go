@@ -0,0 +1,83 @@
+package jibi
+
+// dmaLength is the number of bytes an oam dma copies, one per machine cycle.
+const dmaLength = 0xA0
+
+// A dma is the oam dma. Writing FF46 copies 160 bytes from XX00 to oam, the
+// copy starts after a machine cycle of setup.
+//
+// While it runs oam reads 0xFF and the cpu shares the bus the dma reads from,
+// reads there see the byte being copied and writes are lost. Only the other
+// bus and 0xFF00-0xFFFF, with hram, stay usable.
+type dma struct {
+	reg      Byte // last value written to FF46
+	starting bool // the setup cycle is next
+	active   bool // copying, oam is blocked
+	src      Word
+	index    Word
+	value    Byte // last byte read from the source
+}
+
+// dma buses, the cpu can keep using the one the dma is not reading from
+const (
+	busExternal = iota // rom, cartridge ram and work ram
+	busVRam
+	busInternal // oam, io and hram
+)
+
+func dmaBus(addr Word) int {
+	switch {
+	case addr >= AddrOam:
+		return busInternal
+	case addr >= AddrVRam && addr < AddrERam:
+		return busVRam
+	}
+	return busExternal
+}
+
+// start starts a new copy, a running one is abandoned.
+func (d *dma) start(b Byte) {
+	d.reg = b
+	d.starting = true
+}
+
+// step advances one machine cycle and returns the addresses of the byte to
+// copy in it, ok is false when nothing is copied.
+func (d *dma) step() (src, dst Word, ok bool) {
+	if d.starting {
+		d.starting = false
+		d.active = true
+		d.src = Word(d.reg) << 8
+		if d.src >= AddrEcho {
+			// 0xE000-0xFFFF reads the work ram behind the echo
+			d.src -= AddrEcho - AddrRam
+		}
+		d.index = 0
+		return 0, 0, false
+	}
+	if !d.active {
+		return 0, 0, false
+	}
+	src, dst = d.src+d.index, AddrOam+d.index
+	d.index++
+	if d.index == dmaLength {
+		d.active = false
+	}
+	return src, dst, true
+}
+
+// conflict returns what a cpu access to addr sees instead of memory while
+// the dma runs, ok is false when the access is not affected.
+func (d *dma) conflict(addr Word) (b Byte, ok bool) {
+	switch {
+	case !d.active:
+		return 0, false
+	case AddrOam <= addr && addr < AddrOamEnd:
+		return 0xFF, true
+	case dmaBus(addr) == busInternal:
+		return 0, false
+	case dmaBus(addr) == dmaBus(d.src):
+		return d.value, true
+	}
+	return 0, false
+}
@@ -0,0 +1,112 @@
+package jibi
+
+import (
+	"testing"
+)
+
+func TestDmaTiming(t *testing.T) {
+	var d dma
+	d.start(0xC1)
+
+	// a machine cycle of setup before oam is blocked
+	if _, _, ok := d.step(); ok {
+		t.Error("copied during setup")
+	}
+	for i := Word(0); i < dmaLength; i++ {
+		if _, ok := d.conflict(AddrOam); !ok {
+			t.Fatal("oam not blocked", i)
+		}
+		src, dst, ok := d.step()
+		if !ok || src != 0xC100+i || dst != AddrOam+i {
+			t.Fatalf("0x%04X 0x%04X %v", src, dst, ok)
+		}
+	}
+	if _, _, ok := d.step(); ok {
+		t.Error("copied past the end")
+	}
+	if _, ok := d.conflict(AddrOam); ok {
+		t.Error("oam still blocked")
+	}
+
+	// the echo reads work ram
+	d.start(0xFE)
+	d.step()
+	if src, _, _ := d.step(); src != 0xDE00 {
+		t.Errorf("0x%04X", src)
+	}
+}
+
+func TestDmaConflict(t *testing.T) {
+	var d dma
+	d.start(0xC1)
+	d.step()
+	d.value = 0x42
+
+	// the source bus sees the byte being copied, hram and the other bus
+	// are free
+	for addr, want := range map[Word]int{
+		0x0150: 0x42,
+		0xA000: 0x42,
+		0xC000: 0x42,
+		0x8000: -1,
+		0xFE10: 0xFF,
+		0xFF44: -1,
+		0xFF80: -1,
+		0xFFFF: -1,
+	} {
+		b, ok := d.conflict(addr)
+		if want < 0 && ok || want >= 0 && (!ok || int(b) != want) {
+			t.Errorf("0x%04X: 0x%02X %v", addr, b, ok)
+		}
+	}
+
+	d.start(0x80)
+	d.step()
+	if _, ok := d.conflict(0x0150); ok {
+		t.Error("rom blocked by a vram copy")
+	}
+	if _, ok := d.conflict(0x9000); !ok {
+		t.Error("vram not blocked by a vram copy")
+	}
+}
+
+func TestDma(t *testing.T) {
+	j := newTestJibi([]Byte{
+		0x3E, 0xC1, // 0x0100 LD A, 0xC1
+		0xCD, 0x80, 0xFF, // 0x0102 CALL 0xFF80
+		0x18, 0xFE, // 0x0105 JR -2
+	})
+	defer j.Stop()
+
+	// the usual routine waiting in hram
+	for i, b := range []Byte{
+		0xE0, 0x46, // 0xFF80 LDH (0x46), A
+		0x3E, 0x28, // 0xFF82 LD A, 0x28
+		0x3D,       // 0xFF84 DEC A
+		0x20, 0xFD, // 0xFF85 JR NZ, -3
+		0xC9, // 0xFF87 RET
+	} {
+		j.cpu.writeByte(0xFF80+Word(i), b)
+	}
+	for i := Word(0); i < dmaLength; i++ {
+		j.cpu.writeByte(0xC100+i, Byte(i)^0x5A)
+	}
+
+	for j.cpu.pc != 0x0105 {
+		j.Step()
+		if j.cpu.pc < 0x0100 || j.cpu.pc > 0xFF87 || j.cpu.pc > 0x0107 && j.cpu.pc < 0xFF80 {
+			t.Fatalf("pc 0x%04X", j.cpu.pc)
+		}
+	}
+	if j.cpu.dma.active {
+		t.Fatal("dma still running")
+	}
+	if b := j.cpu.peekByte(AddrDMA); b != 0xC1 {
+		t.Errorf("0x%02X", b)
+	}
+	for i := Word(0); i < dmaLength; i++ {
+		if b := j.cpu.peekByte(AddrOam + i); b != Byte(i)^0x5A {
+			t.Fatalf("0x%04X: 0x%02X", AddrOam+i, b)
+		}
+	}
+}
@@ -23,13 +23,18 @@ type Gpu struct {
 
 	// metrics
 	frameCounters []*Clock
+	frames        uint64 // vblanks since power on
 }
 
 // NewGpu creates a Gpu and starts a goroutine.
 func NewGpu(mmu Mmu, lcd Lcd, clk chan ClockType) *Gpu {
-	commander := NewCommander("gpu")
+	return newGpu(mmu, lcd, clk, NewCommander("gpu"))
+}
+
+func newGpu(mmu Mmu, lcd Lcd, clk chan ClockType, commander *Commander) *Gpu {
 	gpu := &Gpu{CommanderInterface: commander,
 		mmu: mmu, lcd: lcd, clk: clk,
+		mmuKeys:  accessorKeys(AccessGpu),
 		bgBuffer: make([]Byte, 256*256),
 		fgBuffer: make([]Byte, int(lcdWidth)*int(lcdHeight)),
 	}
@@ -381,7 +386,7 @@ func (g *Gpu) stateScanlineOam(first bool, t uint32) (CommanderStateFn, bool, ui
 		} else {
 			stat &= (0x04 ^ 0xFF)
 		}
-		g.writeByte(AddrSTAT, stat)
+		g.mmu.WriteByteAt(AddrSTAT, stat, g.mmuKeys|AddressKeys(abElevated))
 		if (ly == lyc) && (stat&(0x40|0x20) == (0x40 | 0x20)) { // lyc=ly and mode 2
 			g.mmu.SetInterrupt(InterruptLCDC, g.mmuKeys)
 		}
@@ -401,7 +406,7 @@ func (g *Gpu) stateScanlineVram(first bool, t uint32) (CommanderStateFn, bool, u
 		//g.lockAddr(AddrVRam)
 		stat := g.readByte(AddrSTAT)
 		stat = stat&0x7C | 0x3 // mode 3
-		g.writeByte(AddrSTAT, stat)
+		g.mmu.WriteByteAt(AddrSTAT, stat, g.mmuKeys|AddressKeys(abElevated))
 		ly := g.readByte(AddrLY)
 		g.lcd.DrawLine(g.generateLine(ly))
 	}
@@ -429,7 +434,7 @@ func (g *Gpu) stateHblank(first bool, t uint32) (CommanderStateFn, bool, uint32,
 		} else {
 			stat &= (0x04 ^ 0xFF)
 		}
-		g.writeByte(AddrSTAT, stat)
+		g.mmu.WriteByteAt(AddrSTAT, stat, g.mmuKeys|AddressKeys(abElevated))
 		if (ly == lyc) && (stat&(0x40|0x10) == (0x40 | 0x10)) { // lyc=ly and mode 1
 			g.mmu.SetInterrupt(InterruptLCDC, g.mmuKeys)
 		}
@@ -463,13 +468,14 @@ func (g *Gpu) stateVblank(first bool, t uint32) (CommanderStateFn, bool, uint32,
 		} else {
 			stat &= (0x04 ^ 0xFF)
 		}
-		g.writeByte(AddrSTAT, stat)
+		g.mmu.WriteByteAt(AddrSTAT, stat, g.mmuKeys|AddressKeys(abElevated))
 		if (ly == lyc) && (stat&(0x40|0x04) == (0x40 | 0x04)) { // lyc=ly and mode 0
 			g.mmu.SetInterrupt(InterruptLCDC, g.mmuKeys)
 		}
 		g.mmu.SetInterrupt(InterruptVblank, g.mmuKeys)
 		g.lcd.Blank()
 		g.generateFrame()
+		g.frames++
 		for _, clk := range g.frameCounters {
 			clk.AddCycles(1)
 		}
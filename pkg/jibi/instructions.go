@@ -6,19 +6,15 @@ import (
 
 // holds the instruction currently being fetched
 type instruction struct {
-	o opcode
-	p []Byte // params
-}
-
-func newInstruction(o opcode, ps ...Byte) instruction {
-	p := make([]Byte, len(ps))
-	copy(p, ps)
-	return instruction{o, p}
+	o   opcode
+	p   [2]Byte // params, only the first n are valid
+	n   uint8
+	cmd *command
 }
 
 func (i instruction) String() string {
 	ps := ""
-	for _, v := range i.p {
+	for _, v := range i.p[:i.n] {
 		ps += fmt.Sprintf("0x%02X ", v)
 	}
 	return fmt.Sprintf("%s [ 0x%02X %s]", i.o, uint16(i.o), ps)
@@ -26,20 +22,37 @@ func (i instruction) String() string {
 
 // z reset
 // n reset
-// h and c set or reset according to operation
+// h and c set or reset according to the unsigned add of the low byte
 func (c *Cpu) addWordR(a Word, b Byte) Word {
-	sum := a + Word(b)
+	sum := a + Word(int8(b))
+	c.f.reset()
 	// check half carry
-	if (a&0xFF)+Word(b) > 0xFF {
+	if (a&0x0F)+Word(b&0x0F) > 0x0F {
 		c.f.setFlag(flagH)
 	}
 	// check carry
-	if sum < a {
+	if (a&0xFF)+Word(b) > 0xFF {
 		c.f.setFlag(flagC)
 	}
-	// reset other flags
-	c.f.resetFlag(flagZ)
+	return sum
+}
+
+// z not affected
+// n reset
+// h set on carry from bit 11, c set on carry from bit 15
+func (c *Cpu) addWord(a, b Word) Word {
+	sum := a + b
 	c.f.resetFlag(flagN)
+	if (a&0x0FFF)+(b&0x0FFF) > 0x0FFF {
+		c.f.setFlag(flagH)
+	} else {
+		c.f.resetFlag(flagH)
+	}
+	if sum < a {
+		c.f.setFlag(flagC)
+	} else {
+		c.f.resetFlag(flagC)
+	}
 	return sum
 }
 
@@ -58,6 +71,10 @@ func (c *Cpu) res(b uint8, n Byte) Byte {
 	return ((1 << b) ^ 0xFF) & n
 }
 
+func (c *Cpu) set(b uint8, n Byte) Byte {
+	return (1 << b) | n
+}
+
 func (c *Cpu) xor(a, b Byte) Byte {
 	r := a ^ b
 	c.f.reset()
@@ -119,22 +136,20 @@ func (c *Cpu) dec(a Byte) Byte {
 }
 
 func (c *Cpu) sbc(a, b Byte) Byte {
-	fmt.Println(c.str())
-	panic("inst")
 	carry := Byte(0)
 	if c.f.getFlag(flagC) {
 		carry = 1
 	}
-	r := a - (b + carry)
+	r := a - b - carry
 	c.f.reset()
 	if r == 0 {
 		c.f.setFlag(flagZ)
 	}
 	c.f.setFlag(flagN)
-	if a&0x0F < (b&0x0F + carry) {
+	if a&0x0F < b&0x0F+carry {
 		c.f.setFlag(flagH)
 	}
-	if a < b+carry {
+	if uint16(a) < uint16(b)+uint16(carry) {
 		c.f.setFlag(flagC)
 	}
 	return Byte(r)
@@ -190,6 +205,40 @@ func (c *Cpu) add(a, b Byte) Byte {
 	return Byte(r)
 }
 
+// decimal adjust a after a bcd add or subtract
+func (c *Cpu) daa(a Byte) Byte {
+	adjust := Byte(0)
+	carry := c.f.getFlag(flagC)
+	if c.f.getFlag(flagN) {
+		if c.f.getFlag(flagH) {
+			adjust |= 0x06
+		}
+		if carry {
+			adjust |= 0x60
+		}
+		a -= adjust
+	} else {
+		if c.f.getFlag(flagH) || a&0x0F > 0x09 {
+			adjust |= 0x06
+		}
+		if carry || a > 0x99 {
+			adjust |= 0x60
+			carry = true
+		}
+		a += adjust
+	}
+	c.f.resetFlag(flagZ)
+	c.f.resetFlag(flagH)
+	c.f.resetFlag(flagC)
+	if a == 0 {
+		c.f.setFlag(flagZ)
+	}
+	if carry {
+		c.f.setFlag(flagC)
+	}
+	return a
+}
+
 // rotate right through carry (yes, naming is odd)
 func (c *Cpu) rr(n Byte) Byte {
 	r := n >> 1
@@ -235,15 +284,80 @@ func (c *Cpu) rlc(n Byte) Byte {
 	return Byte(r)
 }
 
+// rotate right, old bit 0 to bit 7 and to carry
+func (c *Cpu) rrc(n Byte) Byte {
+	r := n<<7 | n>>1
+	c.f.reset()
+	if r == 0 {
+		c.f.setFlag(flagZ)
+	}
+	if n&0x01 == 0x01 { // carry is old bit 0
+		c.f.setFlag(flagC)
+	}
+	return Byte(r)
+}
+
+// shift left into carry, bit 0 reset
+func (c *Cpu) sla(n Byte) Byte {
+	r := n << 1
+	c.f.reset()
+	if r == 0 {
+		c.f.setFlag(flagZ)
+	}
+	if n&0x80 == 0x80 { // carry is old bit 7
+		c.f.setFlag(flagC)
+	}
+	return Byte(r)
+}
+
+// shift right into carry, bit 7 unchanged
+func (c *Cpu) sra(n Byte) Byte {
+	r := n>>1 | n&0x80
+	c.f.reset()
+	if r == 0 {
+		c.f.setFlag(flagZ)
+	}
+	if n&0x01 == 0x01 { // carry is old bit 0
+		c.f.setFlag(flagC)
+	}
+	return Byte(r)
+}
+
+// shift right into carry, bit 7 reset
+func (c *Cpu) srl(n Byte) Byte {
+	r := n >> 1
+	c.f.reset()
+	if r == 0 {
+		c.f.setFlag(flagZ)
+	}
+	if n&0x01 == 0x01 { // carry is old bit 0
+		c.f.setFlag(flagC)
+	}
+	return Byte(r)
+}
+
+// swap upper and lower nibbles
+func (c *Cpu) swap(n Byte) Byte {
+	r := n<<4 | n>>4
+	c.f.reset()
+	if r == 0 {
+		c.f.setFlag(flagZ)
+	}
+	return Byte(r)
+}
+
+// conditional instructions report the extra cycles of a taken branch
 func (c *Cpu) jrF(f Byte, n int8) {
 	if c.f.getFlag(f) == true {
 		c.jr(n)
+		c.tBranch = 4
 	}
 }
 
 func (c *Cpu) jrNF(f Byte, n int8) {
 	if c.f.getFlag(f) == false {
 		c.jr(n)
+		c.tBranch = 4
 	}
 }
 
@@ -259,11 +373,31 @@ func (c *Cpu) jp(addr Word) {
 	c.pc = register16(addr)
 }
 
+func (c *Cpu) jpF(f Byte, addr Word) {
+	if c.f.getFlag(f) == true {
+		c.jp(addr)
+		c.tBranch = 4
+	}
+}
+
+func (c *Cpu) jpNF(f Byte, addr Word) {
+	if c.f.getFlag(f) == false {
+		c.jp(addr)
+		c.tBranch = 4
+	}
+}
+
 func (c *Cpu) callF(f Byte, addr Word) {
-	fmt.Println(c.str())
-	panic("untested")
 	if c.f.getFlag(f) == true {
 		c.call(addr)
+		c.tBranch = 12
+	}
+}
+
+func (c *Cpu) callNF(f Byte, addr Word) {
+	if c.f.getFlag(f) == false {
+		c.call(addr)
+		c.tBranch = 12
 	}
 }
 
@@ -272,12 +406,87 @@ func (c *Cpu) call(addr Word) {
 	c.jp(addr)
 }
 
+func (c *Cpu) retF(f Byte) {
+	c.tick() // internal cycle checking the condition
+	if c.f.getFlag(f) == true {
+		c.jp(c.pop())
+		c.tBranch = 12
+	}
+}
+
+func (c *Cpu) retNF(f Byte) {
+	c.tick() // internal cycle checking the condition
+	if c.f.getFlag(f) == false {
+		c.jp(c.pop())
+		c.tBranch = 12
+	}
+}
+
+// return and enable interrupts immediately
+func (c *Cpu) reti() {
+	c.jp(c.pop())
+	c.ime = Bit(1)
+	c.imeEnableNext = 0
+}
+
+// enable interrupts after the next instruction, a second EI before then
+// does not extend the delay
+func (c *Cpu) ei() {
+	if c.imeEnableNext == 0 {
+		c.imeEnableNext = 2 // counts down after this and the next instruction
+	}
+}
+
+// disable interrupts immediately, this also cancels a pending EI
+func (c *Cpu) di() {
+	c.ime = Bit(0)
+	c.imeEnableNext = 0
+}
+
+// restart, a call to one of the fixed addresses in page zero
+func (c *Cpu) rst(addr Word) {
+	c.call(addr)
+}
+
 func (c *Cpu) pop() Word {
 	c.sp += 2
 	return c.readWord(c.sp - 2)
 }
 
 func (c *Cpu) push(w Word) {
-	c.sp -= 2
-	c.writeWord(c.sp, w)
+	c.tick() // internal delay before the writes
+	c.sp--
+	c.writeByte(c.sp, w.High())
+	c.sp--
+	c.writeByte(c.sp, w.Low())
+}
+
+// halt stops fetching instructions until an enabled interrupt is pending.
+// With ime reset and an interrupt already pending the cpu does not halt, and
+// instead fails to increment pc on the next fetch (the halt bug).
+func (c *Cpu) halt() {
+	if c.ime == 0 {
+		ie := c.readIo(AddrIE)
+		iflag := c.readIo(AddrIF)
+		if ie&iflag&0x1F != 0 {
+			c.haltBug = true
+			return
+		}
+	}
+	c.halted = true
+}
+
+// stop resets the divider and either performs a pending cgb speed switch or
+// enters stop mode until a joypad input.
+func (c *Cpu) stop() {
+	c.timer.write(AddrDIV, 0)
+	if c.cgb && c.key1&0x01 == 0x01 {
+		c.doubleSpeed = !c.doubleSpeed
+		c.key1 = 0
+		if c.doubleSpeed {
+			c.key1 = 0x80
+		}
+		return
+	}
+	c.stopped = true
 }
@@ -1,17 +1,32 @@
 package jibi
 
-import "os"
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
 
 // Options holds various options.
 type Options struct {
-	Status   bool
-	MaxTicks int
-	LogInst  bool
-	Skipbios bool
-	Render   bool
-	Keypad   bool
-	Squash   bool
-	Every    bool
+	Status        bool
+	MaxTicks      int
+	LogInst       bool
+	LogFormat     string // json or doctor, doctor starts after the bios
+	Skipbios      bool
+	Render        bool
+	Keypad        bool
+	Squash        bool
+	Every         bool
+	CycleAccurate bool       // tick the clock on every cpu memory access
+	TraceFile     string     // binary trace of every instruction
+	Synchronous   bool       // no goroutines, drive with Step, StepFrame and RunCycles
+	SaveFile      string     // battery backed ram is loaded from and saved to this file
+	Strict        bool       // log accesses to unusable memory and unmapped io
+	TimeSource    TimeSource // clock of cartridges with one, time.Now if nil
 }
 
 // Jibi is the glue that holds everything together.
@@ -24,16 +39,41 @@ type Jibi struct {
 	gpu  *Gpu
 	cart *Cartridge
 	kp   *Keypad
+	save *saveFile
 }
 
-// New returns a new Jibi in a Paused state.
+// New returns a new Jibi in a Paused state. A synchronous Jibi is ready to be
+// stepped instead.
 func New(rom []Byte, options Options) Jibi {
+	newCommander := NewCommander
+	if options.Synchronous {
+		newCommander = NewSyncCommander
+	}
+
 	cart := NewCartridge(rom)
+	if options.TimeSource != nil {
+		cart.SetTimeSource(options.TimeSource)
+	}
 	mmu := NewMmu(cart)
-	cpu := NewCpu(mmu, bios)
+	mmu.SetStrict(options.Strict)
+	cpu := newCpu(mmu, bios, newCommander("cpu"))
+	cpu.cycleAccurate = options.CycleAccurate
+	cpu.cgb = cart.color
+	if options.LogInst && options.LogFormat == "doctor" {
+		// reference logs start at 0x0100 and never see LY move
+		options.Skipbios = true
+		cpu.stubLY = true
+	}
 	lcd := NewLcd(options.Squash)
-	gpu := NewGpu(mmu, lcd, cpu.AttachClock())
-	kp := NewKeypad(mmu, options.Keypad)
+	var gpu *Gpu
+	if options.Synchronous {
+		// the gpu runs inside the cpu clock broadcast
+		gpu = newGpu(mmu, lcd, nil, newCommander("gpu"))
+		cpu.clock.AttachFunc(func(t ClockType) { gpu.advance(uint32(t)) })
+	} else {
+		gpu = NewGpu(mmu, lcd, cpu.AttachClock())
+	}
+	kp := newKeypad(mmu, options.Keypad, newCommander("keypad"))
 
 	if options.Skipbios {
 		cpu.RunCommand(CmdUnloadBios, nil)
@@ -42,7 +82,84 @@ func New(rom []Byte, options Options) Jibi {
 		lcd.DisableRender()
 	}
 
-	return Jibi{options, mmu, cpu, lcd, gpu, cart, kp}
+	var save *saveFile
+	if br := cart.BatteryRam(); br != nil && options.SaveFile != "" {
+		cpu.battery = br
+		var err error
+		if save, err = loadSave(options.SaveFile, br); err != nil {
+			// keep the file as it is rather than replace it with empty ram
+			fmt.Fprintln(os.Stderr, "not saving:", err)
+		}
+	}
+
+	j := Jibi{options, mmu, cpu, lcd, gpu, cart, kp, save}
+	if options.Synchronous {
+		j.Play()
+	}
+	return j
+}
+
+// frameCycles is the length of a frame in clock cycles.
+const frameCycles = 70224
+
+func (j Jibi) mustBeSynchronous() {
+	if !j.O.Synchronous {
+		panic("stepping requires the Synchronous option")
+	}
+}
+
+// Step runs one instruction, or one machine cycle while the cpu is halted or
+// stopped, and keeps the other components in step. It returns the clock
+// cycles taken.
+func (j Jibi) Step() ClockType {
+	j.mustBeSynchronous()
+	j.cpu.step(false, 0)
+	return j.cpu.t
+}
+
+// RunCycles steps until at least n clock cycles have run and returns how
+// many did, the last instruction may run past n.
+func (j Jibi) RunCycles(n uint64) uint64 {
+	j.mustBeSynchronous()
+	start := j.cpu.cycles
+	for j.cpu.cycles-start < n {
+		j.cpu.step(false, 0)
+	}
+	return j.cpu.cycles - start
+}
+
+// StepFrame steps until the gpu starts the next vblank and returns the clock
+// cycles run. With the lcd off it runs for the length of a frame.
+func (j Jibi) StepFrame() uint64 {
+	j.mustBeSynchronous()
+	limit := uint64(frameCycles)
+	if j.cpu.doubleSpeed {
+		limit *= 2
+	}
+	frames := j.gpu.frames
+	start := j.cpu.cycles
+	for j.gpu.frames == frames && j.cpu.cycles-start < limit {
+		j.cpu.step(false, 0)
+	}
+	return j.cpu.cycles - start
+}
+
+// AttachRumble returns a channel that gets an event when the cartridge rumble
+// motor turns on or off, or nil for cartridges without a motor. Attach before
+// calling Play or Run.
+func (j Jibi) AttachRumble() chan RumbleEvent {
+	return j.cart.AttachRumble()
+}
+
+// AddWatchpoint registers a memory watchpoint and returns an id for
+// RemoveWatchpoint.
+func (j Jibi) AddWatchpoint(w Watchpoint) int {
+	return j.mmu.AddWatchpoint(w)
+}
+
+// RemoveWatchpoint unregisters a memory watchpoint.
+func (j Jibi) RemoveWatchpoint(id int) {
+	j.mmu.RemoveWatchpoint(id)
 }
 
 // RunCommand displatches a command to the correct piece.
@@ -60,7 +177,8 @@ func (j Jibi) RunCommand(cmd Command, resp chan string) {
 	}
 }
 
-// Run starts the Jibi and waits till it ends before returning.
+// Run starts the Jibi and waits till it ends before returning. It does not
+// work with the Synchronous option.
 func (j Jibi) Run() {
 	// metrics
 	/*
@@ -108,15 +226,31 @@ func (j Jibi) Run() {
 	}
 	totalTicks := int(0)
 
-	var instructions chan string
-	var logFile *os.File
+	lockup := j.cpu.AttachLockup()
+
+	var saveC <-chan time.Time
+	if j.save != nil {
+		saveTicker := time.NewTicker(saveInterval)
+		defer saveTicker.Stop()
+		saveC = saveTicker.C
+	}
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sig)
+
+	var logInst, trace *traceLog
+	var logInstC, traceC chan TraceRecord
 	if j.O.LogInst == true {
-		instructions = j.cpu.AttachInstructions()
-		var err error
-		logFile, err = os.Create("jibi.log")
-		if err != nil {
-			panic(err)
+		newEncoder := NewJSONTraceEncoder
+		if j.O.LogFormat == "doctor" {
+			newEncoder = NewDoctorTraceEncoder
 		}
+		logInst = newTraceLog(j.cpu, "jibi.log", newEncoder)
+		logInstC = logInst.t.C
+	}
+	if j.O.TraceFile != "" {
+		trace = newTraceLog(j.cpu, j.O.TraceFile, NewBinaryTraceEncoder)
+		traceC = trace.t.C
 	}
 	/*
 		cpuHz := float64(0)
@@ -137,9 +271,16 @@ func (j Jibi) Run() {
 			case u := <-inst:
 				fmt.Println(u)
 		*/
-		case s := <-instructions:
-			logFile.WriteString(s)
-			logFile.WriteString("\n")
+		case ev := <-lockup:
+			fmt.Fprintln(os.Stderr, ev)
+		case <-saveC:
+			totalTicks += j.saveRunning(totalTicksClk)
+		case <-sig:
+			running = false
+		case r := <-logInstC:
+			logInst.enc.Encode(r)
+		case r := <-traceC:
+			trace.enc.Encode(r)
 		case t := <-totalTicksClk:
 			totalTicks += int(t)
 			if totalTicks > j.O.MaxTicks {
@@ -226,7 +367,64 @@ func (j Jibi) Run() {
 	/*
 		ticker.Stop()
 	*/
+	totalTicks += j.saveRunning(totalTicksClk)
+	if j.save != nil {
+		j.save.stopped = true
+	}
 	j.Stop()
+	logInst.close()
+	trace.close()
+}
+
+// traceLogSize is how many records can wait for the file before the cpu
+// starts dropping them.
+const traceLogSize = 1 << 16
+
+// A traceLog writes the records of a Tracer to a file.
+type traceLog struct {
+	t   *Tracer
+	f   *os.File
+	w   *bufio.Writer
+	enc TraceEncoder
+}
+
+func newTraceLog(cpu *Cpu, name string, newEncoder func(io.Writer) TraceEncoder) *traceLog {
+	f, err := os.Create(name)
+	if err != nil {
+		panic(err)
+	}
+	w := bufio.NewWriter(f)
+	return &traceLog{cpu.AttachTrace(traceLogSize), f, w, newEncoder(w)}
+}
+
+// close writes the records still waiting and closes the file.
+func (l *traceLog) close() {
+	if l == nil {
+		return
+	}
+	for loop := true; loop; {
+		select {
+		case r := <-l.t.C:
+			l.enc.Encode(r)
+		default:
+			loop = false
+		}
+	}
+	l.w.Flush()
+	l.f.Close()
+	if n := l.t.Dropped(); n > 0 {
+		fmt.Fprintf(os.Stderr, "%s: dropped %d trace records\n", l.f.Name(), n)
+	}
+}
+
+// Registers returns a snapshot of the cpu registers.
+func (j Jibi) Registers() Registers {
+	return j.cpu.Registers()
+}
+
+// SetRegisters overwrites the cpu registers, use it while paused.
+func (j Jibi) SetRegisters(r Registers) {
+	j.cpu.SetRegisters(r)
 }
 
 // Play starts the Jibi and returns immediately.
@@ -239,7 +437,45 @@ func (j Jibi) Pause() {
 	j.RunCommand(CmdPause, nil)
 }
 
-// Stop stops the Jibi and all its goroutines and returns immediately.
+// SaveRam writes the battery backed ram to the save file if it changed. It
+// does nothing without a save file.
+func (j Jibi) SaveRam() error {
+	if j.save == nil || j.save.stopped {
+		return nil
+	}
+	return j.save.write(j.cpu.BatteryRam())
+}
+
+// saveRunning is SaveRam for Run. The cpu can be blocked sending to clk
+// while it is asked for the ram, so clk is drained until it answers, the
+// cycles drained are returned.
+func (j Jibi) saveRunning(clk chan ClockType) (ticks int) {
+	if j.save == nil || j.save.stopped {
+		return 0
+	}
+	resp := make(chan []Byte, 1)
+	j.cpu.RunCommand(CmdGetBatteryRam, resp)
+	for {
+		select {
+		case ram := <-resp:
+			if err := j.save.write(ram); err != nil {
+				fmt.Fprintln(os.Stderr, "saving:", err)
+			}
+			return ticks
+		case t := <-clk:
+			ticks += int(t)
+		}
+	}
+}
+
+// Stop saves the battery backed ram, then stops the Jibi and all its
+// goroutines and returns immediately.
 func (j Jibi) Stop() {
+	if err := j.SaveRam(); err != nil {
+		fmt.Fprintln(os.Stderr, "saving:", err)
+	}
+	if j.save != nil {
+		j.save.stopped = true
+	}
 	j.RunCommand(CmdStop, nil)
 }
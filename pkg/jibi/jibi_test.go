@@ -0,0 +1,75 @@
+package jibi
+
+import (
+	"runtime"
+	"testing"
+)
+
+func newTestJibi(program []Byte) Jibi {
+	rom := make([]Byte, 0x8000)
+	copy(rom[0x0100:], program)
+	return New(rom, Options{Skipbios: true, Synchronous: true})
+}
+
+func TestSynchronous(t *testing.T) {
+	goroutines := runtime.NumGoroutine()
+	j := newTestJibi([]Byte{
+		0x00,             // 0x0100 NOP
+		0xC3, 0x00, 0x01, // 0x0101 JP nn
+	})
+	defer j.Stop()
+	if n := runtime.NumGoroutine(); n != goroutines {
+		t.Fatal(n, goroutines)
+	}
+
+	if c := j.Step(); c != 4 {
+		t.Error(c)
+	}
+	if c := j.Step(); c != 16 {
+		t.Error(c)
+	}
+	if r := j.Registers(); r.PC != 0x0100 || r.Cycles != 20 {
+		t.Errorf("%+v", r)
+	}
+
+	// 12 NOP, JP loops
+	if c := j.RunCycles(100); c != 100 {
+		t.Error(c)
+	}
+	if c := j.RunCycles(1); c != 4 && c != 16 {
+		t.Error(c)
+	}
+}
+
+func TestStepFrame(t *testing.T) {
+	j := newTestJibi([]Byte{
+		0x00,             // 0x0100 NOP
+		0xC3, 0x00, 0x01, // 0x0101 JP nn
+	})
+	defer j.Stop()
+
+	// post boot the lcd is on, the first vblank comes early
+	j.StepFrame()
+	frames := j.gpu.frames
+	if frames != 1 {
+		t.Fatal(frames)
+	}
+	c := j.StepFrame()
+	if j.gpu.frames != frames+1 {
+		t.Error(j.gpu.frames)
+	}
+	// both ends are rounded to an instruction
+	if c < frameCycles-16 || c > frameCycles+16 {
+		t.Error(c)
+	}
+
+	// lcd off, a frame worth of cycles still runs
+	j.cpu.writeByte(AddrLCDC, 0x00)
+	c = j.StepFrame()
+	if j.gpu.frames != frames+1 {
+		t.Error(j.gpu.frames)
+	}
+	if c < frameCycles || c > frameCycles+16 {
+		t.Error(c)
+	}
+}
@@ -71,6 +71,9 @@ type Keypad struct {
 	ttyConfig string
 	runSetup  bool
 
+	// release keys after they stop repeating, the terminal has no key up
+	autoRelease bool
+
 	stty stty
 }
 
@@ -87,7 +90,12 @@ const (
 
 // NewKeypad returns a new Keypad object and starts up a goroutine.
 func NewKeypad(mmu Mmu, runSetup bool) *Keypad {
-	commander := NewCommander("keypad")
+	return newKeypad(mmu, runSetup, NewCommander("keypad"))
+}
+
+// newKeypad only reads the keyboard when the commander has a goroutine, a
+// synchronous keypad keeps keys down until CmdKeyUp.
+func newKeypad(mmu Mmu, runSetup bool, commander *Commander) *Keypad {
 	keys := map[Key]valueChan{
 		// A buffer of 1 is needed because we may get a keydown before the
 		// keyup for that key has been processed. The write to the chan is
@@ -102,7 +110,7 @@ func NewKeypad(mmu Mmu, runSetup bool) *Keypad {
 		KeySelect: valueChan{1, make(chan bool, 1)},
 		KeyStart:  valueChan{1, make(chan bool, 1)},
 	}
-	mmuKeys := AddressKeys(0)
+	mmuKeys := accessorKeys(AccessKeypad)
 	mmuKeys = mmu.LockAddr(AddrP1, mmuKeys)
 	kp := &Keypad{
 		CommanderInterface: commander,
@@ -110,6 +118,7 @@ func NewKeypad(mmu Mmu, runSetup bool) *Keypad {
 		mmuKeys:            mmuKeys,
 		keys:               keys,
 		runSetup:           runSetup,
+		autoRelease:        !commander.sync,
 	}
 	cmdHandlers := map[Command]CommandFn{
 		CmdKeyDown:  kp.cmdKeyDown,
@@ -119,7 +128,9 @@ func NewKeypad(mmu Mmu, runSetup bool) *Keypad {
 	}
 	// no state functions so cmds are synchronous
 	commander.start(nil, cmdHandlers, nil)
-	go kp.loopKeyboard()
+	if !commander.sync {
+		go kp.loopKeyboard()
+	}
 	mmu.SetKeypad(kp)
 	return kp
 }
@@ -149,7 +160,7 @@ func (k *Keypad) Close() {
 }
 
 func (k *Keypad) String() string {
-	resp := make(chan string)
+	resp := make(chan string, 1)
 	k.RunCommand(CmdString, resp)
 	return <-resp
 }
@@ -181,30 +192,32 @@ func (k *Keypad) cmdKeyDown(data interface{}) {
 		if k.keys[key].v == 1 { // inputs are pulled high
 			k.keys[key] = valueChan{0, k.keys[key].c}
 			c := k.keys[key].c
-			go func() {
-				// clear channel
-				for loop := true; loop; {
-					select {
-					case <-c:
-					default:
-						loop = false
-					}
-				}
-				// loop while we get at least one keypress
-				for gotOne := true; gotOne; {
-					timeout := time.After(200 * time.Millisecond)
-					gotOne = false
+			if k.autoRelease {
+				go func() {
+					// clear channel
 					for loop := true; loop; {
 						select {
 						case <-c:
-							gotOne = true
-						case <-timeout:
+						default:
 							loop = false
 						}
 					}
-				}
-				k.RunCommand(CmdKeyUp, data)
-			}()
+					// loop while we get at least one keypress
+					for gotOne := true; gotOne; {
+						timeout := time.After(200 * time.Millisecond)
+						gotOne = false
+						for loop := true; loop; {
+							select {
+							case <-c:
+								gotOne = true
+							case <-timeout:
+								loop = false
+							}
+						}
+					}
+					k.RunCommand(CmdKeyUp, data)
+				}()
+			}
 			k.mmu.SetInterrupt(InterruptKeypad, k.mmuKeys)
 		} else {
 			// this chan has a buffer of 1, so even though the write is
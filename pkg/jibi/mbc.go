@@ -0,0 +1,433 @@
+package jibi
+
+import (
+	"time"
+)
+
+// A Mbc is the memory bank controller of a cartridge. It maps the rom into
+// 0x0000-0x7FFF, takes writes there as control registers, and maps the
+// external ram into 0xA000-0xBFFF.
+type Mbc interface {
+	ReadRom(addr Word) Byte
+	WriteRom(addr Word, b Byte)
+	ReadRam(addr Word) Byte
+	WriteRam(addr Word, b Byte)
+	RomBank() uint16 // rom bank mapped at 0x4000
+}
+
+// A BatteryRam is the part of an Mbc that survives power off on cartridges
+// with a battery.
+type BatteryRam interface {
+	Ram() []Byte // contents to save
+	LoadRam(ram []Byte)
+}
+
+// newMbc returns the controller for the cartridge type.
+func newMbc(ct cartridgeType, rom []Byte, ramSize int) Mbc {
+	switch ct {
+	case 0x01, 0x02, 0x03:
+		return newMbc1(rom, ramSize)
+	case 0x05, 0x06:
+		return newMbc2(rom)
+	case 0x0F, 0x10, 0x11, 0x12, 0x13:
+		return newMbc3(rom, ramSize, ct == 0x0F || ct == 0x10)
+	case 0x19, 0x1A, 0x1B, 0x1C, 0x1D, 0x1E:
+		return newMbc5(rom, ramSize, ct >= 0x1C)
+	}
+	return newRomOnly(rom, ramSize)
+}
+
+// romBanks returns the number of 16 KB banks in rom.
+func romBanks(rom []Byte) int {
+	return len(rom) / 0x4000
+}
+
+// romOnly is a cartridge without a controller, 32 KB of rom and optionally
+// up to 8 KB of ram.
+type romOnly struct {
+	rom []Byte
+	ram []Byte
+}
+
+func newRomOnly(rom []Byte, ramSize int) *romOnly {
+	return &romOnly{rom, make([]Byte, ramSize)}
+}
+
+func (m *romOnly) ReadRom(addr Word) Byte {
+	return m.rom[addr]
+}
+
+func (m *romOnly) WriteRom(addr Word, b Byte) {
+}
+
+func (m *romOnly) ReadRam(addr Word) Byte {
+	if int(addr-AddrERam) >= len(m.ram) {
+		return 0xFF
+	}
+	return m.ram[addr-AddrERam]
+}
+
+func (m *romOnly) WriteRam(addr Word, b Byte) {
+	if int(addr-AddrERam) < len(m.ram) {
+		m.ram[addr-AddrERam] = b
+	}
+}
+
+func (m *romOnly) RomBank() uint16 {
+	return 1
+}
+
+func (m *romOnly) Ram() []Byte {
+	return m.ram
+}
+
+func (m *romOnly) LoadRam(ram []Byte) {
+	copy(m.ram, ram)
+}
+
+// mbc1 supports up to 2 MB of rom and 32 KB of ram.
+type mbc1 struct {
+	rom []Byte
+	ram []Byte
+
+	ramEnable bool
+	bank1     Byte // 5 bit rom bank, 0 selects 1
+	bank2     Byte // 2 bit upper rom bank or ram bank
+	mode      Byte // 1 applies bank2 to 0x0000-0x3FFF and the ram
+}
+
+func newMbc1(rom []Byte, ramSize int) *mbc1 {
+	return &mbc1{rom: rom, ram: make([]Byte, ramSize), bank1: 1}
+}
+
+func (m *mbc1) romOffset(bank int, addr Word) int {
+	bank &= romBanks(m.rom) - 1
+	return bank*0x4000 + int(addr&0x3FFF)
+}
+
+func (m *mbc1) ReadRom(addr Word) Byte {
+	if addr < 0x4000 {
+		bank := 0
+		if m.mode == 1 {
+			bank = int(m.bank2) << 5
+		}
+		return m.rom[m.romOffset(bank, addr)]
+	}
+	return m.rom[m.romOffset(int(m.RomBank()), addr)]
+}
+
+func (m *mbc1) WriteRom(addr Word, b Byte) {
+	switch {
+	case addr < 0x2000:
+		m.ramEnable = b&0x0F == 0x0A
+	case addr < 0x4000:
+		// only the 5 bits are checked for 0, so banks 0x20, 0x40 and 0x60
+		// become 0x21, 0x41 and 0x61
+		m.bank1 = b & 0x1F
+		if m.bank1 == 0 {
+			m.bank1 = 1
+		}
+	case addr < 0x6000:
+		m.bank2 = b & 0x03
+	default:
+		m.mode = b & 0x01
+	}
+}
+
+func (m *mbc1) RomBank() uint16 {
+	bank := int(m.bank2)<<5 | int(m.bank1)
+	return uint16(bank & (romBanks(m.rom) - 1))
+}
+
+func (m *mbc1) ramOffset(addr Word) int {
+	bank := 0
+	if m.mode == 1 {
+		bank = int(m.bank2)
+	}
+	return (bank*0x2000 + int(addr-AddrERam)) % len(m.ram)
+}
+
+func (m *mbc1) ReadRam(addr Word) Byte {
+	if !m.ramEnable || len(m.ram) == 0 {
+		return 0xFF
+	}
+	return m.ram[m.ramOffset(addr)]
+}
+
+func (m *mbc1) WriteRam(addr Word, b Byte) {
+	if m.ramEnable && len(m.ram) > 0 {
+		m.ram[m.ramOffset(addr)] = b
+	}
+}
+
+func (m *mbc1) Ram() []Byte {
+	return m.ram
+}
+
+func (m *mbc1) LoadRam(ram []Byte) {
+	copy(m.ram, ram)
+}
+
+// mbc2 supports up to 256 KB of rom and has 512 half bytes of ram built in.
+type mbc2 struct {
+	rom []Byte
+	ram []Byte // one nibble per byte
+
+	ramEnable bool
+	bank      Byte
+}
+
+func newMbc2(rom []Byte) *mbc2 {
+	return &mbc2{rom: rom, ram: make([]Byte, 0x200), bank: 1}
+}
+
+func (m *mbc2) ReadRom(addr Word) Byte {
+	if addr < 0x4000 {
+		return m.rom[addr]
+	}
+	bank := int(m.bank) & (romBanks(m.rom) - 1)
+	return m.rom[bank*0x4000+int(addr&0x3FFF)]
+}
+
+// WriteRom uses address bit 8 to pick the register below 0x4000.
+func (m *mbc2) WriteRom(addr Word, b Byte) {
+	if addr >= 0x4000 {
+		return
+	}
+	if addr&0x0100 == 0 {
+		m.ramEnable = b&0x0F == 0x0A
+	} else {
+		m.bank = b & 0x0F
+		if m.bank == 0 {
+			m.bank = 1
+		}
+	}
+}
+
+func (m *mbc2) RomBank() uint16 {
+	return uint16(int(m.bank) & (romBanks(m.rom) - 1))
+}
+
+// ReadRam reads the ram mirrored across 0xA000-0xBFFF, the upper nibble is
+// not connected and reads 1s.
+func (m *mbc2) ReadRam(addr Word) Byte {
+	if !m.ramEnable {
+		return 0xFF
+	}
+	return m.ram[addr&0x01FF] | 0xF0
+}
+
+func (m *mbc2) WriteRam(addr Word, b Byte) {
+	if m.ramEnable {
+		m.ram[addr&0x01FF] = b & 0x0F
+	}
+}
+
+func (m *mbc2) Ram() []Byte {
+	return m.ram
+}
+
+func (m *mbc2) LoadRam(ram []Byte) {
+	for i := range m.ram {
+		if i < len(ram) {
+			m.ram[i] = ram[i] & 0x0F
+		}
+	}
+}
+
+// mbc3 supports up to 2 MB of rom, 32 KB of ram and optionally a real time
+// clock whose registers are mapped in place of the ram.
+type mbc3 struct {
+	rom []Byte
+	ram []Byte
+	rtc *rtc // nil without a clock
+
+	ramEnable bool
+	bank      Byte // 7 bit rom bank, 0 selects 1
+	ramBank   Byte // 0x00-0x03 ram bank, 0x08-0x0C rtc register
+	latch     Byte // last write to 0x6000-0x7FFF
+}
+
+func newMbc3(rom []Byte, ramSize int, clock bool) *mbc3 {
+	m := &mbc3{rom: rom, ram: make([]Byte, ramSize), bank: 1, latch: 0xFF}
+	if clock {
+		m.rtc = newRtc(time.Now)
+	}
+	return m
+}
+
+func (m *mbc3) ReadRom(addr Word) Byte {
+	if addr < 0x4000 {
+		return m.rom[addr]
+	}
+	return m.rom[int(m.RomBank())*0x4000+int(addr&0x3FFF)]
+}
+
+func (m *mbc3) WriteRom(addr Word, b Byte) {
+	switch {
+	case addr < 0x2000:
+		m.ramEnable = b&0x0F == 0x0A
+	case addr < 0x4000:
+		m.bank = b & 0x7F
+		if m.bank == 0 {
+			m.bank = 1
+		}
+	case addr < 0x6000:
+		m.ramBank = b & 0x0F
+	default:
+		// the registers are latched by writing 0x00 then 0x01
+		if m.latch == 0x00 && b == 0x01 && m.rtc != nil {
+			m.rtc.latch()
+		}
+		m.latch = b
+	}
+}
+
+func (m *mbc3) RomBank() uint16 {
+	return uint16(int(m.bank) & (romBanks(m.rom) - 1))
+}
+
+func (m *mbc3) isRtc() bool {
+	return m.rtc != nil && m.ramBank >= rtcS && m.ramBank <= rtcDH
+}
+
+func (m *mbc3) ramOffset(addr Word) int {
+	return (int(m.ramBank&0x03)*0x2000 + int(addr-AddrERam)) % len(m.ram)
+}
+
+func (m *mbc3) ReadRam(addr Word) Byte {
+	switch {
+	case !m.ramEnable:
+		return 0xFF
+	case m.isRtc():
+		return m.rtc.read(m.ramBank)
+	case m.ramBank > 0x03 || len(m.ram) == 0:
+		return 0xFF
+	}
+	return m.ram[m.ramOffset(addr)]
+}
+
+func (m *mbc3) WriteRam(addr Word, b Byte) {
+	switch {
+	case !m.ramEnable:
+	case m.isRtc():
+		m.rtc.write(m.ramBank, b)
+	case m.ramBank <= 0x03 && len(m.ram) > 0:
+		m.ram[m.ramOffset(addr)] = b
+	}
+}
+
+// Ram returns the ram followed by the clock when there is one.
+func (m *mbc3) Ram() []Byte {
+	if m.rtc == nil {
+		return m.ram
+	}
+	return append(append([]Byte{}, m.ram...), m.rtc.save()...)
+}
+
+func (m *mbc3) LoadRam(ram []Byte) {
+	copy(m.ram, ram)
+	if m.rtc != nil && len(ram) >= len(m.ram)+rtcSaveSize {
+		m.rtc.load(ram[len(m.ram):])
+	}
+}
+
+// A RumbleEvent reports the rumble motor of the cartridge turning on or off.
+type RumbleEvent struct {
+	On bool
+}
+
+// mbc5 supports up to 8 MB of rom and 128 KB of ram, some cartridges use a
+// ram bank bit to drive a rumble motor instead.
+type mbc5 struct {
+	rom []Byte
+	ram []Byte
+
+	ramEnable bool
+	bank      uint16 // 9 bit rom bank, 0 is not remapped
+	ramBank   Byte
+
+	rumble       bool // bit 3 of the ram bank drives the motor
+	motor        bool
+	notifyRumble []chan RumbleEvent
+}
+
+func newMbc5(rom []Byte, ramSize int, rumble bool) *mbc5 {
+	return &mbc5{rom: rom, ram: make([]Byte, ramSize), bank: 1, rumble: rumble}
+}
+
+func (m *mbc5) ReadRom(addr Word) Byte {
+	if addr < 0x4000 {
+		return m.rom[addr]
+	}
+	return m.rom[int(m.RomBank())*0x4000+int(addr&0x3FFF)]
+}
+
+func (m *mbc5) WriteRom(addr Word, b Byte) {
+	switch {
+	case addr < 0x2000:
+		m.ramEnable = b&0x0F == 0x0A
+	case addr < 0x3000:
+		m.bank = m.bank&0x100 | uint16(b)
+	case addr < 0x4000:
+		m.bank = uint16(b&0x01)<<8 | m.bank&0xFF
+	case addr < 0x6000:
+		m.ramBank = b & 0x0F
+		if m.rumble {
+			m.ramBank &= 0x07
+			m.setMotor(b&0x08 != 0)
+		}
+	}
+}
+
+func (m *mbc5) RomBank() uint16 {
+	return m.bank & uint16(romBanks(m.rom)-1)
+}
+
+func (m *mbc5) ramOffset(addr Word) int {
+	return (int(m.ramBank)*0x2000 + int(addr-AddrERam)) % len(m.ram)
+}
+
+func (m *mbc5) ReadRam(addr Word) Byte {
+	if !m.ramEnable || len(m.ram) == 0 {
+		return 0xFF
+	}
+	return m.ram[m.ramOffset(addr)]
+}
+
+func (m *mbc5) WriteRam(addr Word, b Byte) {
+	if m.ramEnable && len(m.ram) > 0 {
+		m.ram[m.ramOffset(addr)] = b
+	}
+}
+
+func (m *mbc5) Ram() []Byte {
+	return m.ram
+}
+
+func (m *mbc5) LoadRam(ram []Byte) {
+	copy(m.ram, ram)
+}
+
+// return a channel that gets an event when the motor turns on or off
+func (m *mbc5) attachRumble() chan RumbleEvent {
+	// games pulse the motor to set its strength, a slow reader only misses
+	// the pulses
+	rumble := make(chan RumbleEvent, 16)
+	m.notifyRumble = append(m.notifyRumble, rumble)
+	return rumble
+}
+
+func (m *mbc5) setMotor(on bool) {
+	if on == m.motor {
+		return
+	}
+	m.motor = on
+	ev := RumbleEvent{on}
+	for _, rumble := range m.notifyRumble {
+		select {
+		case rumble <- ev:
+		default:
+		}
+	}
+}
@@ -0,0 +1,394 @@
+package jibi
+
+import (
+	"testing"
+	"time"
+)
+
+// newBankedRom returns a rom where every bank starts with its bank number.
+func newBankedRom(banks int, ct cartridgeType, ramSize cartridgeRamSize) []Byte {
+	rom := make([]Byte, banks*0x4000)
+	for bank := 0; bank < banks; bank++ {
+		rom[bank*0x4000] = Byte(bank)
+		rom[bank*0x4000+1] = Byte(bank >> 8)
+	}
+	rom[0x0147] = Byte(ct)
+	rom[0x0149] = Byte(ramSize)
+	return rom
+}
+
+func TestMbc1Rom(t *testing.T) {
+	cart := NewCartridge(newBankedRom(128, 0x01, 0x00))
+	m := cart.mbc
+
+	// bank 0 selects bank 1
+	if m.ReadRom(0x4000) != 1 || m.RomBank() != 1 {
+		t.Error(m.ReadRom(0x4000))
+	}
+	m.WriteRom(0x2000, 0x00)
+	if m.ReadRom(0x4000) != 1 {
+		t.Error(m.ReadRom(0x4000))
+	}
+	m.WriteRom(0x3FFF, 0x1F)
+	if m.ReadRom(0x4000) != 0x1F {
+		t.Error(m.ReadRom(0x4000))
+	}
+
+	// the upper bits come from bank2, 0x20 becomes 0x21
+	m.WriteRom(0x4000, 0x01)
+	if m.ReadRom(0x4000) != 0x3F {
+		t.Error(m.ReadRom(0x4000))
+	}
+	m.WriteRom(0x2000, 0x20)
+	if m.ReadRom(0x4000) != 0x21 || m.RomBank() != 0x21 {
+		t.Error(m.ReadRom(0x4000))
+	}
+	m.WriteRom(0x5000, 0x03)
+	if m.ReadRom(0x4000) != 0x61 {
+		t.Error(m.ReadRom(0x4000))
+	}
+
+	// mode 1 also maps bank2 into 0x0000-0x3FFF
+	if m.ReadRom(0x0000) != 0x00 {
+		t.Error(m.ReadRom(0x0000))
+	}
+	m.WriteRom(0x6000, 0x01)
+	if m.ReadRom(0x0000) != 0x60 {
+		t.Error(m.ReadRom(0x0000))
+	}
+	m.WriteRom(0x6000, 0x00)
+	if m.ReadRom(0x0000) != 0x00 {
+		t.Error(m.ReadRom(0x0000))
+	}
+
+	// banks past the end of the rom wrap
+	cart = NewCartridge(newBankedRom(8, 0x01, 0x00))
+	m = cart.mbc
+	m.WriteRom(0x2000, 0x09)
+	if m.ReadRom(0x4000) != 0x01 {
+		t.Error(m.ReadRom(0x4000))
+	}
+}
+
+func TestMbc1Ram(t *testing.T) {
+	cart := NewCartridge(newBankedRom(4, 0x03, 0x03))
+	m := cart.mbc
+
+	// disabled ram reads 0xFF and ignores writes
+	m.WriteRam(0xA000, 0x12)
+	if m.ReadRam(0xA000) != 0xFF {
+		t.Error(m.ReadRam(0xA000))
+	}
+	m.WriteRom(0x0000, 0x0A)
+	if m.ReadRam(0xA000) != 0x00 {
+		t.Error(m.ReadRam(0xA000))
+	}
+
+	// ram banks only switch in mode 1
+	m.WriteRam(0xA000, 0x10)
+	m.WriteRom(0x4000, 0x02)
+	m.WriteRam(0xA000, 0x11)
+	if m.ReadRam(0xA000) != 0x11 {
+		t.Error(m.ReadRam(0xA000))
+	}
+	m.WriteRom(0x6000, 0x01)
+	if m.ReadRam(0xA000) != 0x00 {
+		t.Error(m.ReadRam(0xA000))
+	}
+	m.WriteRam(0xBFFF, 0x22)
+	m.WriteRom(0x6000, 0x00)
+	if m.ReadRam(0xA000) != 0x11 || m.ReadRam(0xBFFF) != 0x00 {
+		t.Error(m.ReadRam(0xA000))
+	}
+
+	// any value without 0x0A in the low nibble disables
+	m.WriteRom(0x1FFF, 0x1B)
+	if m.ReadRam(0xA000) != 0xFF {
+		t.Error(m.ReadRam(0xA000))
+	}
+}
+
+func TestMbc1Mmu(t *testing.T) {
+	mmu := NewMmu(NewCartridge(newBankedRom(8, 0x03, 0x02)))
+	ak := mmu.LockAddr(AddrRom, 0)
+	ak = mmu.LockAddr(AddrERam, ak)
+
+	mmu.WriteByteAt(0x2100, 0x05, ak)
+	if mmu.ReadByteAt(0x4000, ak) != 0x05 || mmu.RomBank() != 5 {
+		t.Error(mmu.ReadByteAt(0x4000, ak))
+	}
+	mmu.WriteByteAt(0x0000, 0x0A, ak)
+	mmu.WriteByteAt(0xA123, 0x42, ak)
+	if mmu.ReadByteAt(0xA123, ak) != 0x42 {
+		t.Error(mmu.ReadByteAt(0xA123, ak))
+	}
+}
+
+func TestMbc2(t *testing.T) {
+	cart := NewCartridge(newBankedRom(16, 0x06, 0x00))
+	m := cart.mbc
+
+	// address bit 8 picks the register, bank 0 selects 1
+	m.WriteRom(0x2100, 0x05)
+	if m.ReadRom(0x4000) != 0x05 || m.RomBank() != 0x05 {
+		t.Error(m.ReadRom(0x4000))
+	}
+	m.WriteRom(0x0100, 0x00)
+	if m.ReadRom(0x4000) != 0x01 {
+		t.Error(m.ReadRom(0x4000))
+	}
+	m.WriteRom(0x3FFF, 0x1F)
+	if m.ReadRom(0x4000) != 0x0F {
+		t.Error(m.ReadRom(0x4000))
+	}
+
+	// writes with bit 8 clear enable the ram and leave the bank alone
+	m.WriteRam(0xA000, 0x05)
+	if m.ReadRam(0xA000) != 0xFF {
+		t.Error(m.ReadRam(0xA000))
+	}
+	m.WriteRom(0x3EFF, 0x0A)
+	if m.ReadRom(0x4000) != 0x0F {
+		t.Error(m.ReadRom(0x4000))
+	}
+
+	// only the low nibble is stored and the ram repeats every 512 bytes
+	m.WriteRam(0xA000, 0xA5)
+	if m.ReadRam(0xA000) != 0xF5 {
+		t.Error(m.ReadRam(0xA000))
+	}
+	m.WriteRam(0xBFFF, 0x03)
+	if m.ReadRam(0xA1FF) != 0xF3 || m.ReadRam(0xA200) != 0xF5 {
+		t.Error(m.ReadRam(0xA1FF), m.ReadRam(0xA200))
+	}
+
+	br := cart.BatteryRam()
+	if br == nil || len(br.Ram()) != 0x200 || br.Ram()[0x1FF] != 0x03 {
+		t.Fatal(br)
+	}
+	br.LoadRam([]Byte{0xFC})
+	if m.ReadRam(0xA000) != 0xFC {
+		t.Error(m.ReadRam(0xA000))
+	}
+
+	// without a battery there is nothing to keep
+	if NewCartridge(newBankedRom(16, 0x05, 0x00)).BatteryRam() != nil {
+		t.Error("0x05 has no battery")
+	}
+}
+
+func TestMbc3(t *testing.T) {
+	cart := NewCartridge(newBankedRom(128, 0x13, 0x03))
+	m := cart.mbc
+
+	// 7 bit rom bank, 0 selects 1
+	m.WriteRom(0x2000, 0x7F)
+	if m.ReadRom(0x4000) != 0x7F || m.RomBank() != 0x7F {
+		t.Error(m.ReadRom(0x4000))
+	}
+	m.WriteRom(0x2000, 0x00)
+	if m.ReadRom(0x4000) != 0x01 {
+		t.Error(m.ReadRom(0x4000))
+	}
+
+	// four ram banks
+	m.WriteRom(0x0000, 0x0A)
+	for bank := Byte(0); bank < 4; bank++ {
+		m.WriteRom(0x4000, bank)
+		m.WriteRam(0xA000, 0x10+bank)
+	}
+	for bank := Byte(0); bank < 4; bank++ {
+		m.WriteRom(0x4000, bank)
+		if m.ReadRam(0xA000) != 0x10+bank {
+			t.Error(bank, m.ReadRam(0xA000))
+		}
+	}
+
+	// no clock on this type
+	m.WriteRom(0x4000, 0x08)
+	if m.ReadRam(0xA000) != 0xFF {
+		t.Error(m.ReadRam(0xA000))
+	}
+}
+
+// fakeTime is a TimeSource moved by hand.
+type fakeTime struct {
+	t time.Time
+}
+
+func (f *fakeTime) now() time.Time {
+	return f.t
+}
+
+func TestMbc3Rtc(t *testing.T) {
+	clock := &fakeTime{time.Unix(1000000, 0)}
+	cart := NewCartridge(newBankedRom(4, 0x10, 0x02))
+	cart.SetTimeSource(clock.now)
+	m := cart.mbc
+	m.WriteRom(0x0000, 0x0A)
+
+	read := func(reg Byte) Byte {
+		m.WriteRom(0x4000, reg)
+		return m.ReadRam(0xA000)
+	}
+	latch := func() {
+		m.WriteRom(0x6000, 0x00)
+		m.WriteRom(0x6000, 0x01)
+	}
+
+	// reads see the latched registers
+	clock.t = clock.t.Add(90 * time.Second)
+	if read(rtcS) != 0 {
+		t.Error(read(rtcS))
+	}
+	latch()
+	if read(rtcS) != 30 || read(rtcM) != 1 {
+		t.Error(read(rtcM), read(rtcS))
+	}
+	clock.t = clock.t.Add(time.Second)
+	if read(rtcS) != 30 {
+		t.Error(read(rtcS))
+	}
+
+	// only a 0 then 1 write latches
+	m.WriteRom(0x6000, 0x01)
+	if read(rtcS) != 30 {
+		t.Error(read(rtcS))
+	}
+	latch()
+	if read(rtcS) != 31 {
+		t.Error(read(rtcS))
+	}
+
+	// the day counter carries out of bit 8
+	clock.t = clock.t.Add(511 * 24 * time.Hour)
+	latch()
+	if read(rtcDL) != 0xFF || read(rtcDH) != rtcDayHigh {
+		t.Error(read(rtcDL), read(rtcDH))
+	}
+	clock.t = clock.t.Add(24 * time.Hour)
+	latch()
+	if read(rtcDL) != 0x00 || read(rtcDH) != rtcCarry {
+		t.Error(read(rtcDL), read(rtcDH))
+	}
+
+	// halted clocks do not count
+	m.WriteRom(0x4000, rtcDH)
+	m.WriteRam(0xA000, rtcHalt)
+	m.WriteRom(0x4000, rtcH)
+	m.WriteRam(0xA000, 23)
+	clock.t = clock.t.Add(time.Hour)
+	latch()
+	if read(rtcH) != 23 || read(rtcDL) != 0 {
+		t.Error(read(rtcH), read(rtcDL))
+	}
+	m.WriteRom(0x4000, rtcDH)
+	m.WriteRam(0xA000, 0x00)
+	clock.t = clock.t.Add(time.Hour)
+	latch()
+	if read(rtcH) != 0 || read(rtcDL) != 1 {
+		t.Error(read(rtcH), read(rtcDL))
+	}
+
+	// the clock is saved after the ram and keeps going while off
+	m.WriteRom(0x4000, 0x00)
+	m.WriteRam(0xA000, 0x42)
+	save := cart.BatteryRam().Ram()
+	if len(save) != 0x2000+rtcSaveSize {
+		t.Fatal(len(save))
+	}
+	clock.t = clock.t.Add(5 * time.Minute)
+	cart = NewCartridge(newBankedRom(4, 0x10, 0x02))
+	cart.SetTimeSource(clock.now)
+	cart.BatteryRam().LoadRam(save)
+	m = cart.mbc
+	m.WriteRom(0x0000, 0x0A)
+	latch()
+	if read(0x00) != 0x42 || read(rtcM) != 6 || read(rtcDL) != 1 {
+		t.Error(read(0x00), read(rtcM), read(rtcDL))
+	}
+}
+
+func TestMbc3RtcTimeSource(t *testing.T) {
+	clock := &fakeTime{time.Unix(1000000, 0)}
+	j := New(newBankedRom(4, 0x10, 0x02),
+		Options{Skipbios: true, Synchronous: true, TimeSource: clock.now})
+	defer j.Stop()
+
+	clock.t = clock.t.Add(125 * time.Second)
+	j.cpu.writeByte(0x0000, 0x0A)
+	j.cpu.writeByte(0x6000, 0x00)
+	j.cpu.writeByte(0x6000, 0x01)
+	j.cpu.writeByte(0x4000, rtcS)
+	s := j.cpu.peekByte(0xA000)
+	j.cpu.writeByte(0x4000, rtcM)
+	if m := j.cpu.peekByte(0xA000); s != 5 || m != 2 {
+		t.Error(m, s)
+	}
+}
+
+func TestMbc5(t *testing.T) {
+	cart := NewCartridge(newBankedRom(512, 0x1B, 0x04))
+	m := cart.mbc
+
+	// 9 bit rom bank, bank 0 can be mapped at 0x4000
+	m.WriteRom(0x2000, 0x00)
+	if m.ReadRom(0x4000) != 0x00 || m.RomBank() != 0 {
+		t.Error(m.ReadRom(0x4000))
+	}
+	m.WriteRom(0x3000, 0x01)
+	m.WriteRom(0x2FFF, 0x23)
+	if m.ReadRom(0x4000) != 0x23 || m.ReadRom(0x4001) != 0x01 || m.RomBank() != 0x123 {
+		t.Error(m.ReadRom(0x4000), m.ReadRom(0x4001))
+	}
+
+	// sixteen ram banks
+	m.WriteRom(0x0000, 0x0A)
+	for bank := Byte(0); bank < 16; bank++ {
+		m.WriteRom(0x4000, bank)
+		m.WriteRam(0xBFFF, 0x20+bank)
+	}
+	for bank := Byte(0); bank < 16; bank++ {
+		m.WriteRom(0x4000, bank)
+		if m.ReadRam(0xBFFF) != 0x20+bank {
+			t.Error(bank, m.ReadRam(0xBFFF))
+		}
+	}
+	if cart.AttachRumble() != nil {
+		t.Error("0x1B has no motor")
+	}
+}
+
+func TestMbc5Rumble(t *testing.T) {
+	cart := NewCartridge(newBankedRom(4, 0x1D, 0x03))
+	m := cart.mbc
+	rumble := cart.AttachRumble()
+
+	// bit 3 drives the motor and is not a ram bank bit
+	m.WriteRom(0x0000, 0x0A)
+	m.WriteRom(0x4000, 0x01)
+	m.WriteRam(0xA000, 0x11)
+	m.WriteRom(0x4000, 0x09)
+	if m.ReadRam(0xA000) != 0x11 {
+		t.Error(m.ReadRam(0xA000))
+	}
+	m.WriteRom(0x4000, 0x08)
+	m.WriteRom(0x4000, 0x00)
+	m.WriteRom(0x4000, 0x00)
+
+	for _, on := range []bool{true, false} {
+		select {
+		case ev := <-rumble:
+			if ev.On != on {
+				t.Error(ev)
+			}
+		default:
+			t.Fatal("missing event")
+		}
+	}
+	select {
+	case ev := <-rumble:
+		t.Error("unexpected", ev)
+	default:
+	}
+}
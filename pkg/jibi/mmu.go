@@ -2,7 +2,9 @@ package jibi
 
 import (
 	"fmt"
+	"os"
 	"sync"
+	"sync/atomic"
 )
 
 // A list of all the special memory addresses.
@@ -11,9 +13,11 @@ const (
 	AddrVRam   Word = 0x8000
 	AddrERam   Word = 0xA000
 	AddrRam    Word = 0xC000
+	AddrEcho   Word = 0xE000 // mirror of 0xC000-0xDDFF
 	AddrOam    Word = 0xFE00
-	AddrOamEnd Word = 0xFEA0
+	AddrOamEnd Word = 0xFEA0 // unusable up to 0xFEFF
 
+	AddrIo   Word = 0xFF00
 	AddrP1   Word = 0xFF00
 	AddrDIV  Word = 0xFF04
 	AddrTIMA Word = 0xFF05
@@ -35,6 +39,7 @@ const (
 	AddrWY         Word = 0xFF4A
 	AddrWX         Word = 0xFF4B
 	AddrGpuRegsEnd Word = 0xFF4C
+	AddrKEY1       Word = 0xFF4D
 
 	AddrZero Word = 0xFF80
 	AddrIE   Word = 0xFFFF
@@ -52,21 +57,23 @@ type Mmu interface {
 	SetKeypad(kp *Keypad)
 	SetGpu(gpu *Gpu)
 	SetInterrupt(in Interrupt, ak AddressKeys)
+	SetStrict(strict bool) // log accesses to unusable memory and unmapped io
+	RomBank() uint16       // rom bank mapped at 0x4000
+	AddWatchpoint(w Watchpoint) int
+	RemoveWatchpoint(id int)
+	Fetch(addr Word, b Byte, ak AddressKeys) // an opcode that bypassed ReadByteAt
 }
 
 type RomOnlyMmu struct {
 	// memory blocks and io
-	rom     []Byte
+	mbc     Mbc
 	vram    []Byte
 	ram     []Byte
 	oam     []Byte
 	ioP1    *mmio
-	div     Byte
-	tima    Byte
-	tma     Byte
-	tac     Byte
 	ioIF    *mmio
 	gpuregs []Byte
+	io      []Byte // io registers without an address block
 	zero    []Byte
 	ie      Byte
 
@@ -74,32 +81,38 @@ type RomOnlyMmu struct {
 	locks []*sync.Mutex
 
 	// internal state
-	kp  *Keypad
-	gpu *Gpu
+	kp     *Keypad
+	gpu    *Gpu
+	strict bool
+
+	// watchpoints, replaced on every change so accesses need no lock
+	watches   atomic.Pointer[[]watch]
+	watchLock sync.Mutex
+	nextWatch int
+	pc        Word // instruction the cpu is running
 }
 
 // NewMmu creates a new Mmu with an optional bios that replaces 0x0000-0x00FF.
 func NewMmu(cart *Cartridge) Mmu {
-	var rom []Byte
+	var mbc Mbc
 	if cart != nil {
-		rom = cart.Rom
+		mbc = cart.mbc
+	} else {
+		mbc = newRomOnly(make([]Byte, 0x8000), 0)
 	}
 	locks := make([]*sync.Mutex, abLast+1)
 	for i := uint16(1); i <= uint16(abLast); i = i << 1 {
 		locks[i] = new(sync.Mutex)
 	}
 	mmu := &RomOnlyMmu{
-		rom:     rom,
+		mbc:     mbc,
 		vram:    make([]Byte, 0x2000),
 		ram:     make([]Byte, 0x2000),
 		oam:     make([]Byte, 0xA0),
 		ioP1:    newMmio(AddrP1),
-		div:     Byte(0),
-		tima:    Byte(0),
-		tma:     Byte(0),
-		tac:     Byte(0),
 		ioIF:    newMmio(AddrIF),
 		gpuregs: make([]Byte, 12),
+		io:      make([]Byte, 0x80),
 		zero:    make([]Byte, 0x100),
 		locks:   locks,
 	}
@@ -117,10 +130,6 @@ const (
 	abRam
 	abOam
 	abP1
-	abDIV
-	abTIMA
-	abTMA
-	abTAC
 	abIF
 	abGpuRegs
 	abZero
@@ -163,7 +172,15 @@ func (m *RomOnlyMmu) SetGpu(gpu *Gpu) {
 	m.gpu = gpu
 }
 
-func (m *RomOnlyMmu) selectAddressBlock(addr Word, rw string) (addressBlock, Word) {
+func (m *RomOnlyMmu) SetStrict(strict bool) {
+	m.strict = strict
+}
+
+func (m *RomOnlyMmu) RomBank() uint16 {
+	return m.mbc.RomBank()
+}
+
+func (m *RomOnlyMmu) selectAddressBlock(addr Word) (addressBlock, Word) {
 	if addr < AddrVRam {
 		return abRom, 0
 	} else if AddrVRam <= addr && addr < AddrERam {
@@ -171,19 +188,12 @@ func (m *RomOnlyMmu) selectAddressBlock(addr Word, rw string) (addressBlock, Wor
 	} else if AddrERam <= addr && addr < AddrRam {
 		return abERam, AddrERam
 	} else if AddrRam <= addr && addr < AddrOam {
+		// includes the echo, the ram index is masked
 		return abRam, AddrRam
 	} else if AddrOam <= addr && addr < AddrOamEnd {
 		return abOam, AddrOam
 	} else if AddrP1 == addr {
 		return abP1, AddrP1
-	} else if AddrDIV == addr {
-		return abDIV, AddrDIV
-	} else if AddrTIMA == addr {
-		return abTIMA, AddrTIMA
-	} else if AddrTMA == addr {
-		return abTMA, AddrTMA
-	} else if AddrTAC == addr {
-		return abTAC, AddrTAC
 	} else if AddrIF == addr {
 		return abIF, AddrIF
 	} else if AddrGpuRegs <= addr && addr < AddrGpuRegsEnd {
@@ -193,21 +203,17 @@ func (m *RomOnlyMmu) selectAddressBlock(addr Word, rw string) (addressBlock, Wor
 	} else if AddrIE == addr {
 		return abIE, AddrIE
 	}
-
-	u, v := m.getAddressInfo(addr)
-	if !v {
-		if rw == "" {
-			rw = "access"
-		}
-		panic(fmt.Sprintf("unhandled memory %s: 0x%04X - %s", rw, addr, u))
-	}
+	// unusable memory and the io registers without a block
 	return abNil, 0
 }
 
 // LockAddr gets a lock for an address if not already in the provided
 // AddressKeys and appends it and returns this new key set.
 func (m *RomOnlyMmu) LockAddr(addr Word, ak AddressKeys) AddressKeys {
-	blk, _ := m.selectAddressBlock(addr, "lock")
+	blk, _ := m.selectAddressBlock(addr)
+	if blk == abNil {
+		panic(fmt.Sprintf("lock of unmapped memory: 0x%04X", addr))
+	}
 	if addressBlock(ak)&blk == blk {
 		// already have the key
 		return ak
@@ -217,7 +223,7 @@ func (m *RomOnlyMmu) LockAddr(addr Word, ak AddressKeys) AddressKeys {
 }
 
 func (m *RomOnlyMmu) UnlockAddr(addr Word, ak AddressKeys) AddressKeys {
-	blk, _ := m.selectAddressBlock(addr, "unlock")
+	blk, _ := m.selectAddressBlock(addr)
 	if addressBlock(ak)&blk != blk {
 		// don't have the key
 		return ak
@@ -227,17 +233,27 @@ func (m *RomOnlyMmu) UnlockAddr(addr Word, ak AddressKeys) AddressKeys {
 }
 
 func (m *RomOnlyMmu) ReadByteAt(addr Word, ak AddressKeys) Byte {
-	blk, start := m.selectAddressBlock(addr, "read")
+	b := m.readByteAt(addr, ak)
+	m.watchRead(addr, b, ak)
+	return b
+}
+
+func (m *RomOnlyMmu) readByteAt(addr Word, ak AddressKeys) Byte {
+	blk, start := m.selectAddressBlock(addr)
 	owner := addressBlock(ak)&blk == blk
 	if blk == abRom {
 		if owner {
-			return m.rom[addr-start]
+			return m.mbc.ReadRom(addr)
 		}
 	}
 	if blk == abVRam {
 		if owner {
 			return m.vram[addr-start]
 		}
+	} else if blk == abERam {
+		if owner {
+			return m.mbc.ReadRam(addr)
+		}
 	} else if blk == abRam {
 		if owner {
 			return m.ram[(addr-start)&0x1FFF]
@@ -248,22 +264,6 @@ func (m *RomOnlyMmu) ReadByteAt(addr Word, ak AddressKeys) Byte {
 		}
 	} else if blk == abP1 {
 		return m.ioP1.readByte(owner)
-	} else if blk == abDIV {
-		if owner {
-			return m.div
-		}
-	} else if blk == abTIMA {
-		if owner {
-			return m.tima
-		}
-	} else if blk == abTMA {
-		if owner {
-			return m.tma
-		}
-	} else if blk == abTAC {
-		if owner {
-			return m.tac
-		}
 	} else if blk == abIF {
 		return m.ioIF.readByte(owner)
 	} else if blk == abGpuRegs {
@@ -278,27 +278,39 @@ func (m *RomOnlyMmu) ReadByteAt(addr Word, ak AddressKeys) Byte {
 		if owner {
 			return m.ie
 		}
+	} else if blk == abNil {
+		return m.readUnmapped(addr, ak)
 	}
-	if u, v := m.getAddressInfo(addr); !v {
-		if !owner {
-			panic(fmt.Sprintf("unauthorized read: 0x%04X", addr))
-		}
-		panic(fmt.Sprintf("unhandled memory read: 0x%04X - %s", addr, u))
-	}
-	return 0
+	panic(fmt.Sprintf("unauthorized read: 0x%04X", addr))
 }
 
 func (m *RomOnlyMmu) WriteByteAt(addr Word, b Byte, ak AddressKeys) {
-	blk, start := m.selectAddressBlock(addr, "write")
+	if hits := m.watching(WatchWrite, addr, ak); len(hits) > 0 {
+		old := m.readByteAt(addr, ak|akUnwatched)
+		m.writeByteAt(addr, b, ak)
+		m.fire(hits, MemoryAccess{Kind: WatchWrite, Addr: addr, Value: b, Old: old}, ak)
+		return
+	}
+	m.writeByteAt(addr, b, ak)
+}
+
+func (m *RomOnlyMmu) writeByteAt(addr Word, b Byte, ak AddressKeys) {
+	blk, start := m.selectAddressBlock(addr)
 	owner := addressBlock(ak)&blk == blk
 	elevated := addressBlock(ak)&abElevated == abElevated
 	if blk == abRom {
+		m.mbc.WriteRom(addr, b)
 		return
 	} else if blk == abVRam {
 		if owner {
 			m.vram[addr-start] = b
 			return
 		}
+	} else if blk == abERam {
+		if owner {
+			m.mbc.WriteRam(addr, b)
+			return
+		}
 	} else if blk == abRam {
 		if owner {
 			m.ram[(addr-start)&0x1FFF] = b
@@ -315,30 +327,6 @@ func (m *RomOnlyMmu) WriteByteAt(addr Word, b Byte, ak AddressKeys) {
 			m.kp.RunCommand(CmdKeyCheck, nil)
 		}
 		return
-	} else if blk == abDIV {
-		if owner {
-			if elevated {
-				m.div = b // reset on write
-			} else {
-				m.div = Byte(0)
-			}
-			return
-		}
-	} else if blk == abTIMA {
-		if owner {
-			m.tima = b
-			return
-		}
-	} else if blk == abTMA {
-		if owner {
-			m.tma = b
-			return
-		}
-	} else if blk == abTAC {
-		if owner {
-			m.tac = b
-			return
-		}
 	} else if blk == abIF {
 		m.ioIF.writeByte(b, owner)
 		return
@@ -360,6 +348,9 @@ func (m *RomOnlyMmu) WriteByteAt(addr Word, b Byte, ak AddressKeys) {
 				} else {
 					m.gpuregs[addr-start] = b
 				}
+			} else if addr == AddrSTAT && !elevated {
+				// the mode and coincidence bits belong to the gpu
+				m.gpuregs[addr-start] = m.gpuregs[addr-start]&0x07 | b&0x78
 			} else {
 				m.gpuregs[addr-start] = b
 			}
@@ -375,17 +366,15 @@ func (m *RomOnlyMmu) WriteByteAt(addr Word, b Byte, ak AddressKeys) {
 			m.ie = b
 			return
 		}
+	} else if blk == abNil {
+		m.writeUnmapped(addr, b)
+		return
 	}
-	if u, v := m.getAddressInfo(addr); !v {
-		if !owner {
-			panic(fmt.Sprintf("unauthorized write: 0x%04X 0x%02X", addr, b))
-		}
-		panic(fmt.Sprintf("unhandled memory write: 0x%04X - %s", addr, u))
-	}
+	panic(fmt.Sprintf("unauthorized write: 0x%04X 0x%02X", addr, b))
 }
 
 func (m *RomOnlyMmu) ReadIoByte(addr Word, ak AddressKeys) (Byte, bool) {
-	blk, _ := m.selectAddressBlock(addr, "write")
+	blk, _ := m.selectAddressBlock(addr)
 	owner := addressBlock(ak)&blk == blk
 	if blk == abP1 {
 		return m.ioP1.readIoByte(owner)
@@ -395,93 +384,96 @@ func (m *RomOnlyMmu) ReadIoByte(addr Word, ak AddressKeys) (Byte, bool) {
 	panic(fmt.Sprintf("unhandled queued write: 0x%04X", addr))
 }
 
-// incomplete, used for debugging
-// a return value of true means we can ignore this address
-func (m *RomOnlyMmu) getAddressInfo(addr Word) (string, bool) {
-	if 0x9C00 <= addr && addr <= 0x9FFF {
-		return "Background Map Data 2", false
-	} else if 0xA000 <= addr && addr <= 0xBFFF {
-		return "Cartridge Ram", true // TODO: find out what should happen in rom only
-	} else if 0xFEA0 <= addr && addr <= 0xFEFF {
-		return "unusable memory", true
-	} else if addr == 0xFF00 {
-		return "Register for reading joy pad info and determining system type. (R/W)", false
-	} else if addr == 0xFF01 {
-		return "Serial transfer data (R/W)", true
-	} else if addr == 0xFF02 {
-		return "SIO control (R/W)", true
-	} else if addr == 0xFF03 {
-		return "no clue", true
-	} else if addr == 0xFF04 {
-		return "DIV", false
-	} else if addr == 0xFF05 {
-		return "TIMA", false
-	} else if addr == 0xFF06 {
-		return "TMA", false
-	} else if addr == 0xFF07 {
-		return "TAC", false
-	} else if 0xFF08 <= addr && addr <= 0xFF0E {
-		return "no clue", true
-	} else if addr == 0xFF10 {
-		return "Sound Mode 1 register, Sweep register (R/W)", true
-	} else if addr == 0xFF11 {
-		return "Sound Mode 1 register, Sound length/Wave pattern duty (R/W)", true
-	} else if addr == 0xFF12 {
-		return "Sound Mode 1 register, Envelope (R/W)", true
-	} else if addr == 0xFF13 {
-		return "Sound Mode 1 register, Frequency lo (W)", true
-	} else if addr == 0xFF14 {
-		return "Sound Mode 1 register, Frequency hi (R/W)", true
-	} else if addr == 0xFF15 {
-		return "no clue", true
-	} else if addr == 0xFF16 {
-		return "Sound Mode 2 register, Sound Length; Wave Pattern Duty (R/W)", true
-	} else if addr == 0xFF17 {
-		return "Sound Mode 2 register, envelope (R/W)", true
-	} else if addr == 0xFF18 {
-		return "Sound Mode 2 register, frequency lo data (W)", true
-	} else if addr == 0xFF19 {
-		return "Sound Mode 2 register, frequency", true
-	} else if addr == 0xFF1A {
-		return "Sound Mode 3 register, Sound on/off (R/W)", true
-	} else if addr == 0xFF1B {
-		return "Sound Mode 3 register, sound length (R/W)", true
-	} else if addr == 0xFF1C {
-		return "Sound Mode 3 register, Select output level (R/W)", true
-	} else if addr == 0xFF1D {
-		return "Sound Mode 3 register, frequency's lower data (W)", true
-	} else if addr == 0xFF1E {
-		return "Sound Mode 3 register, frequency's higher data (R/W)", true
-	} else if addr == 0xFF1F {
-		return "no clue", true
-	} else if addr == 0xFF20 {
-		return "Sound Mode 4 register, sound length (R/W)", true
-	} else if addr == 0xFF21 {
-		return "Sound Mode 4 register, envelope (R/W)", true
-	} else if addr == 0xFF22 {
-		return "Sound Mode 4 register, polynomial counter (R/W)", true
-	} else if addr == 0xFF23 {
-		return "Sound Mode 4 register, counter/consecutive; inital (R/W)", true
-	} else if addr == 0xFF24 {
-		return "Channel control / ON-OFF / Volume (R/W)", true
-	} else if addr == 0xFF25 {
-		return "Selection of Sound output terminal (R/W)", true
-	} else if addr == 0xFF26 {
-		return "Sound on/off (R/W)", true
-	} else if 0xFF27 <= addr && addr <= 0xFF2F {
-		return "no clue", true
-	} else if 0xFF30 <= addr && addr <= 0xFF3F {
-		return "Sound Sample RAM", true
-	} else if addr == 0xFF47 {
-		return "BGP", false
-	} else if 0xFF4C == addr {
-		return "no clue", true
-	} else if 0xFF4D <= addr && addr <= 0xFF7F {
-		return "GBC", true
-	} else if addr == 0xFFFF {
-		return "IE", false
+// An ioRegister is an io register kept in RomOnlyMmu.io. None of them are
+// emulated, they only hold what was written.
+type ioRegister struct {
+	name string
+	mask Byte // bits that always read 1
+}
+
+// ioRegisters lists the io registers without their own block, the rest of
+// 0xFF00-0xFF7F is unmapped. The timer and KEY1 live in the cpu.
+var ioRegisters = map[Word]ioRegister{
+	0xFF01: {"SB", 0x00},
+	0xFF02: {"SC", 0x7E},
+	0xFF10: {"NR10", 0x80},
+	0xFF11: {"NR11", 0x3F},
+	0xFF12: {"NR12", 0x00},
+	0xFF13: {"NR13", 0xFF},
+	0xFF14: {"NR14", 0xBF},
+	0xFF16: {"NR21", 0x3F},
+	0xFF17: {"NR22", 0x00},
+	0xFF18: {"NR23", 0xFF},
+	0xFF19: {"NR24", 0xBF},
+	0xFF1A: {"NR30", 0x7F},
+	0xFF1B: {"NR31", 0xFF},
+	0xFF1C: {"NR32", 0x9F},
+	0xFF1D: {"NR33", 0xFF},
+	0xFF1E: {"NR34", 0xBF},
+	0xFF20: {"NR41", 0xFF},
+	0xFF21: {"NR42", 0x00},
+	0xFF22: {"NR43", 0x00},
+	0xFF23: {"NR44", 0xBF},
+	0xFF24: {"NR50", 0x00},
+	0xFF25: {"NR51", 0x00},
+	0xFF26: {"NR52", 0x70},
+}
+
+// ioRegisterAt returns the register at addr, the wave ram is a block of
+// plain registers.
+func ioRegisterAt(addr Word) (ioRegister, bool) {
+	if 0xFF30 <= addr && addr <= 0xFF3F {
+		return ioRegister{"wave ram", 0x00}, true
+	}
+	r, ok := ioRegisters[addr]
+	return r, ok
+}
+
+// readUnmapped reads unusable memory and the io registers without a block.
+// Unusable memory reads 0x00, as on a dmg while oam is accessible, the gpu
+// never blocks oam here. Io not backed by a register reads 0xFF, the value of
+// the open bus.
+func (m *RomOnlyMmu) readUnmapped(addr Word, ak AddressKeys) Byte {
+	b := Byte(0xFF)
+	if addr >= AddrIo {
+		if r, ok := ioRegisterAt(addr); ok {
+			return m.io[addr-AddrIo] | r.mask
+		}
+	} else {
+		b = 0x00
+	}
+	if ak&akUnwatched == 0 {
+		m.logUnmapped("read", addr, b)
+	}
+	return b
+}
+
+// writeUnmapped writes unusable memory and the io registers without a block,
+// writes to unmapped addresses are dropped.
+func (m *RomOnlyMmu) writeUnmapped(addr Word, b Byte) {
+	if addr >= AddrIo {
+		if _, ok := ioRegisterAt(addr); ok {
+			if addr == 0xFF26 {
+				// only the power bit of NR52 is writable, the channel bits
+				// report the sound hardware
+				b = m.io[addr-AddrIo]&0x0F | b&0x80
+			}
+			m.io[addr-AddrIo] = b
+			return
+		}
+	}
+	m.logUnmapped("write", addr, b)
+}
+
+func (m *RomOnlyMmu) logUnmapped(rw string, addr Word, b Byte) {
+	if !m.strict {
+		return
+	}
+	what := "unmapped io"
+	if addr < AddrIo {
+		what = "unusable memory"
 	}
-	return "unknown", false
+	fmt.Fprintf(os.Stderr, "mmu: %s of %s: 0x%04X 0x%02X\n", rw, what, addr, b)
 }
 
 // memory mapped io
@@ -0,0 +1,97 @@
+package jibi
+
+import (
+	"testing"
+)
+
+func TestMmuEcho(t *testing.T) {
+	mmu := NewMmu(nil)
+	ak := mmu.LockAddr(AddrRam, 0)
+
+	mmu.WriteByteAt(0xC123, 0x42, ak)
+	if b := mmu.ReadByteAt(0xE123, ak); b != 0x42 {
+		t.Error(b)
+	}
+	mmu.WriteByteAt(0xFDFF, 0x24, ak)
+	if b := mmu.ReadByteAt(0xDDFF, ak); b != 0x24 {
+		t.Error(b)
+	}
+}
+
+func TestMmuUnmapped(t *testing.T) {
+	for _, strict := range []bool{false, true} {
+		mmu := NewMmu(nil)
+		mmu.SetStrict(strict)
+
+		// unusable memory reads 0x00, unmapped io reads the open bus
+		for addr, want := range map[Word]Byte{
+			0xFEA0: 0x00,
+			0xFEFF: 0x00,
+			0xFF03: 0xFF,
+			0xFF15: 0xFF,
+			0xFF27: 0xFF,
+			0xFF4C: 0xFF,
+			0xFF7F: 0xFF,
+		} {
+			mmu.WriteByteAt(addr, 0x5A, 0)
+			if b := mmu.ReadByteAt(addr, 0); b != want {
+				t.Errorf("0x%04X: 0x%02X", addr, b)
+			}
+		}
+	}
+}
+
+func TestMmuIoRegisters(t *testing.T) {
+	mmu := NewMmu(nil)
+
+	// unused and write only bits read 1
+	for addr, want := range map[Word]Byte{
+		0xFF01: 0x00, // SB
+		0xFF02: 0x7E, // SC
+		0xFF10: 0x80, // NR10
+		0xFF13: 0xFF, // NR13
+		0xFF14: 0xBF, // NR14
+		0xFF1C: 0x9F, // NR32
+		0xFF30: 0x00, // wave ram
+		0xFF3F: 0x00,
+	} {
+		mmu.WriteByteAt(addr, 0x00, 0)
+		if b := mmu.ReadByteAt(addr, 0); b != want {
+			t.Errorf("0x%04X: 0x%02X", addr, b)
+		}
+	}
+	mmu.WriteByteAt(0xFF3F, 0x5A, 0)
+	if b := mmu.ReadByteAt(0xFF3F, 0); b != 0x5A {
+		t.Error(b)
+	}
+
+	// only the NR52 power bit is writable
+	mmu.WriteByteAt(0xFF26, 0xFF, 0)
+	if b := mmu.ReadByteAt(0xFF26, 0); b != 0xF0 {
+		t.Errorf("0x%02X", b)
+	}
+}
+
+func TestMmuReadOnlyBits(t *testing.T) {
+	j := newTestJibi(nil)
+	defer j.Stop()
+
+	// the cpu cannot change the STAT mode and coincidence bits
+	ak := j.mmu.LockAddr(AddrGpuRegs, 0)
+	j.mmu.WriteByteAt(AddrSTAT, 0x03, ak|AddressKeys(abElevated))
+	j.mmu.UnlockAddr(AddrGpuRegs, ak)
+	j.cpu.writeByte(AddrSTAT, 0xFC)
+	if b := j.cpu.peekByte(AddrSTAT); b != 0xFB {
+		t.Errorf("0x%02X", b)
+	}
+	j.cpu.writeByte(AddrSTAT, 0x00)
+	if b := j.cpu.peekByte(AddrSTAT); b != 0x83 {
+		t.Errorf("0x%02X", b)
+	}
+
+	// the upper IF bits read 1
+	j.cpu.writeByte(AddrIF, 0x01)
+	if b := j.cpu.peekByte(AddrIF); b != 0xE1 {
+		t.Errorf("0x%02X", b)
+	}
+}
@@ -36,3 +36,20 @@ func (tm TestMmu) SetKeypad(kp *Keypad) {
 
 func (tm TestMmu) SetInterrupt(in Interrupt, ak AddressKeys) {
 }
+
+func (tm TestMmu) SetStrict(strict bool) {
+}
+
+func (tm TestMmu) AddWatchpoint(w Watchpoint) int {
+	return 0
+}
+
+func (tm TestMmu) RemoveWatchpoint(id int) {
+}
+
+func (tm TestMmu) Fetch(addr Word, b Byte, ak AddressKeys) {
+}
+
+func (tm TestMmu) RomBank() uint16 {
+	return 1
+}
@@ -0,0 +1,138 @@
+package jibi
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// A TimeSource returns the current time. The cartridge clock advances by the
+// time passed between calls, so tests and replays can run on a fake clock.
+type TimeSource func() time.Time
+
+// rtc register numbers as selected through 0x4000-0x5FFF
+const (
+	rtcS  Byte = 0x08 + iota // seconds
+	rtcM                     // minutes
+	rtcH                     // hours
+	rtcDL                    // low 8 bits of the day counter
+	rtcDH                    // bit 0 day counter bit 8, bit 6 halt, bit 7 carry
+)
+
+const (
+	rtcDayHigh Byte = 0x01
+	rtcHalt    Byte = 0x40
+	rtcCarry   Byte = 0x80
+)
+
+// rtcSaveSize is the size of the clock appended to the ram in save files,
+// the layout most emulators use: the registers and the latched registers as
+// 32 bit little endian values followed by a 64 bit unix timestamp.
+const rtcSaveSize = 48
+
+// An rtc is the real time clock of an MBC3. The registers are brought up to
+// date from the time source whenever they are accessed.
+type rtc struct {
+	now  TimeSource
+	last time.Time // time the registers were last brought up to date
+
+	regs    [5]Byte
+	latched [5]Byte
+}
+
+func newRtc(now TimeSource) *rtc {
+	return &rtc{now: now, last: now()}
+}
+
+// update adds the whole seconds passed since the last update, the rest is
+// kept for the next one.
+func (r *rtc) update() {
+	now := r.now()
+	d := now.Sub(r.last)
+	if r.regs[rtcDH-rtcS]&rtcHalt != 0 || d < 0 {
+		r.last = now
+		return
+	}
+	secs := int64(d / time.Second)
+	if secs == 0 {
+		return
+	}
+	r.last = r.last.Add(time.Duration(secs) * time.Second)
+
+	// out of range values written by the game are normalized here rather
+	// than counting up to the register width like the hardware does
+	regs := &r.regs
+	dh := regs[rtcDH-rtcS]
+	days := int64(dh&rtcDayHigh)<<8 | int64(regs[rtcDL-rtcS])
+	total := days*86400 + int64(regs[rtcH-rtcS])*3600 +
+		int64(regs[rtcM-rtcS])*60 + int64(regs[rtcS-rtcS]) + secs
+	days = total / 86400
+	if days >= 512 {
+		dh |= rtcCarry
+		days %= 512
+	}
+	regs[rtcS-rtcS] = Byte(total % 60)
+	regs[rtcM-rtcS] = Byte(total / 60 % 60)
+	regs[rtcH-rtcS] = Byte(total / 3600 % 24)
+	regs[rtcDL-rtcS] = Byte(days)
+	regs[rtcDH-rtcS] = dh&^rtcDayHigh | Byte(days>>8)&rtcDayHigh
+}
+
+// latch copies the running registers to the ones the game reads.
+func (r *rtc) latch() {
+	r.update()
+	r.latched = r.regs
+}
+
+func (r *rtc) read(reg Byte) Byte {
+	return r.latched[reg-rtcS]
+}
+
+func (r *rtc) write(reg Byte, b Byte) {
+	r.update()
+	switch reg {
+	case rtcS:
+		b &= 0x3F
+		// writing the seconds resets the sub-second counter
+		r.last = r.now()
+	case rtcM:
+		b &= 0x3F
+	case rtcH:
+		b &= 0x1F
+	case rtcDH:
+		b &= rtcDayHigh | rtcHalt | rtcCarry
+	}
+	r.regs[reg-rtcS] = b
+	r.latched[reg-rtcS] = b
+}
+
+func (r *rtc) save() []Byte {
+	r.update()
+	bs := make([]byte, rtcSaveSize)
+	for i := range r.regs {
+		binary.LittleEndian.PutUint32(bs[i*4:], uint32(r.regs[i]))
+		binary.LittleEndian.PutUint32(bs[20+i*4:], uint32(r.latched[i]))
+	}
+	binary.LittleEndian.PutUint64(bs[40:], uint64(r.last.Unix()))
+	data := make([]Byte, rtcSaveSize)
+	for i, b := range bs {
+		data[i] = Byte(b)
+	}
+	return data
+}
+
+// load restores a clock written by save, the time passed since it was saved
+// is added on the next access.
+func (r *rtc) load(data []Byte) {
+	if len(data) < rtcSaveSize {
+		return
+	}
+	bs := make([]byte, rtcSaveSize)
+	for i := range bs {
+		bs[i] = byte(data[i])
+	}
+	for i := range r.regs {
+		r.regs[i] = Byte(binary.LittleEndian.Uint32(bs[i*4:]))
+		r.latched[i] = Byte(binary.LittleEndian.Uint32(bs[20+i*4:]))
+	}
+	r.last = time.Unix(int64(binary.LittleEndian.Uint64(bs[40:])), 0)
+}
@@ -0,0 +1,81 @@
+package jibi
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// saveInterval is how often Run writes the battery ram while playing.
+const saveInterval = 5 * time.Second
+
+// A saveFile keeps the battery backed ram of a cartridge in a file.
+type saveFile struct {
+	name    string
+	last    []byte // contents of the file, writes are skipped if unchanged
+	stopped bool   // the final write on Stop happened
+}
+
+// loadSave loads the file into the cartridge ram, a missing file leaves the
+// ram as it is.
+func loadSave(name string, br BatteryRam) (*saveFile, error) {
+	s := &saveFile{name: name}
+	buf, err := os.ReadFile(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	} else if err != nil {
+		return nil, err
+	}
+	ram := make([]Byte, len(buf))
+	for i, b := range buf {
+		ram[i] = Byte(b)
+	}
+	br.LoadRam(ram)
+	s.last = buf
+	return s, nil
+}
+
+// write replaces the file with ram if it changed since the last write.
+func (s *saveFile) write(ram []Byte) error {
+	buf := make([]byte, len(ram))
+	for i, b := range ram {
+		buf[i] = byte(b)
+	}
+	if s.last != nil && bytes.Equal(buf, s.last) {
+		return nil
+	}
+	if err := writeFileAtomic(s.name, buf); err != nil {
+		return err
+	}
+	s.last = buf
+	return nil
+}
+
+// writeFileAtomic writes to a temporary file next to name and renames it
+// over name, so a crash leaves either the old or the new contents.
+func writeFileAtomic(name string, buf []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	err = f.Chmod(0644)
+	if err == nil {
+		_, err = f.Write(buf)
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, name)
+	}
+	if err != nil {
+		os.Remove(tmp)
+	}
+	return err
+}
@@ -0,0 +1,127 @@
+package jibi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newSaveJibi(name string) Jibi {
+	return New(saveRom(), Options{Skipbios: true, Synchronous: true, SaveFile: name})
+}
+
+// saveRom writes 0x42 to the start of its battery backed ram.
+func saveRom() []Byte {
+	rom := make([]Byte, 0x8000)
+	copy(rom[0x0100:], []Byte{
+		0x3E, 0x0A, // 0x0100 LD A, 0x0A
+		0xEA, 0x00, 0x00, // 0x0102 LD (0x0000), A enables the ram
+		0x3E, 0x42, // 0x0105 LD A, 0x42
+		0xEA, 0x00, 0xA0, // 0x0107 LD (0xA000), A
+		0x18, 0xFE, // 0x010A JR -2
+	})
+	rom[0x0147] = 0x03 // MBC1+RAM+BATT
+	rom[0x0149] = 0x02
+	return rom
+}
+
+func TestSaveRam(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "game.sav")
+
+	// a new game starts without a file and writes one on Stop
+	j := newSaveJibi(name)
+	if _, err := os.Stat(name); !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	j.RunCycles(100)
+	j.Stop()
+	buf, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buf) != 0x2000 || buf[0] != 0x42 {
+		t.Fatal(len(buf), buf[0])
+	}
+
+	// the next run starts from the file
+	buf[1] = 0x24
+	if err := os.WriteFile(name, buf, 0644); err != nil {
+		t.Fatal(err)
+	}
+	j = newSaveJibi(name)
+	ram := j.cpu.BatteryRam()
+	if ram[0] != 0x42 || ram[1] != 0x24 {
+		t.Error(ram[0], ram[1])
+	}
+
+	// unchanged ram is not written again
+	os.Remove(name)
+	if err := j.SaveRam(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(name); !os.IsNotExist(err) {
+		t.Error(err)
+	}
+	j.Stop()
+
+	// no temporary files are left behind
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Error(entries)
+	}
+}
+
+func TestSaveRamUnreadable(t *testing.T) {
+	// a save that cannot be read is never replaced
+	name := t.TempDir()
+	j := newSaveJibi(name)
+	j.RunCycles(100)
+	j.Stop()
+	if fi, err := os.Stat(name); err != nil || !fi.IsDir() {
+		t.Error(fi, err)
+	}
+}
+
+func TestSaveRamMaxTicks(t *testing.T) {
+	// the cpu blocks on the tick clock of MaxTicks while Run saves
+	name := filepath.Join(t.TempDir(), "game.sav")
+	j := New(saveRom(), Options{Skipbios: true, MaxTicks: 10000, SaveFile: name})
+	done := make(chan struct{})
+	go func() {
+		j.Run()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Run did not return")
+	}
+	buf, err := os.ReadFile(name)
+	if err != nil || buf[0] != 0x42 {
+		t.Fatal(buf, err)
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "game.sav")
+	if err := writeFileAtomic(name, []byte{1, 2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFileAtomic(name, []byte{3}); err != nil {
+		t.Fatal(err)
+	}
+	buf, err := os.ReadFile(name)
+	if err != nil || len(buf) != 1 || buf[0] != 3 {
+		t.Error(buf, err)
+	}
+
+	// a failed write leaves the old file alone
+	if err := writeFileAtomic(filepath.Join(name, "x"), []byte{4}); err == nil {
+		t.Error("expected an error")
+	}
+	if buf, _ := os.ReadFile(name); len(buf) != 1 || buf[0] != 3 {
+		t.Error(buf)
+	}
+}
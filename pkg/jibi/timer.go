@@ -0,0 +1,99 @@
+package jibi
+
+// A timer holds DIV, TIMA, TMA and TAC. Everything is driven by the 16 bit
+// system counter, DIV is its high byte and TIMA counts the falling edges of
+// the counter bit selected by TAC.
+type timer struct {
+	counter Word
+	tima    Byte
+	tma     Byte
+	tac     Byte
+
+	// TIMA overflowed and reads 0x00, TMA is loaded on the next machine
+	// cycle, a TIMA write before then cancels the reload
+	overflow bool
+
+	// TMA was loaded during the current machine cycle, TIMA writes are
+	// ignored and TMA writes also go to TIMA
+	reloaded bool
+}
+
+// counter bit watched for each TAC clock select
+var timerBits = [4]Word{
+	0x0200, // 4096 Hz
+	0x0008, // 262144 Hz
+	0x0020, // 65536 Hz
+	0x0080, // 16384 Hz
+}
+
+// signal is the input of the falling edge detector.
+func (t *timer) signal() bool {
+	return t.tac&0x04 != 0 && t.counter&timerBits[t.tac&0x03] != 0
+}
+
+func (t *timer) inc() {
+	t.tima++
+	if t.tima == 0 {
+		t.overflow = true
+	}
+}
+
+// run advances the timer by c clock cycles one machine cycle at a time and
+// returns true if a timer interrupt was requested.
+func (t *timer) run(c ClockType) bool {
+	interrupt := false
+	for ; c >= 4; c -= 4 {
+		t.reloaded = false
+		if t.overflow {
+			t.overflow = false
+			t.reloaded = true
+			t.tima = t.tma
+			interrupt = true
+		}
+		prev := t.signal()
+		t.counter += 4
+		if prev && !t.signal() {
+			t.inc()
+		}
+	}
+	return interrupt
+}
+
+func (t *timer) read(addr Word) Byte {
+	switch addr {
+	case AddrDIV:
+		return t.counter.High()
+	case AddrTIMA:
+		return t.tima
+	case AddrTMA:
+		return t.tma
+	case AddrTAC:
+		return t.tac | 0xF8 // unused bits read 1
+	}
+	return 0xFF
+}
+
+func (t *timer) write(addr Word, b Byte) {
+	prev := t.signal()
+	switch addr {
+	case AddrDIV:
+		t.counter = 0
+	case AddrTIMA:
+		if !t.reloaded {
+			t.tima = b
+			t.overflow = false
+		}
+	case AddrTMA:
+		t.tma = b
+		if t.reloaded {
+			t.tima = b
+		}
+	case AddrTAC:
+		t.tac = b & 0x07
+	}
+	// resetting the counter or changing TAC can make the edge detector
+	// see a falling edge
+	if prev && !t.signal() {
+		t.inc()
+	}
+}
@@ -0,0 +1,145 @@
+package jibi
+
+import (
+	"testing"
+)
+
+func TestTimerFallingEdge(t *testing.T) {
+	for tac, period := range []int{1024, 16, 64, 256} {
+		tm := timer{}
+		tm.write(AddrTAC, 0x04|Byte(tac))
+		for i := 0; i < period/4; i++ {
+			tm.run(4)
+		}
+		if tm.tima != 1 {
+			t.Error(tac, tm.tima)
+		}
+		for i := 0; i < period/4-1; i++ {
+			tm.run(4)
+		}
+		if tm.tima != 1 {
+			t.Error(tac, tm.tima)
+		}
+		tm.run(4)
+		if tm.tima != 2 {
+			t.Error(tac, tm.tima)
+		}
+	}
+
+	// disabled
+	tm := timer{}
+	tm.write(AddrTAC, 0x01)
+	tm.run(64)
+	if tm.tima != 0 || tm.read(AddrDIV) != 0 || tm.counter != 64 {
+		t.Error(tm)
+	}
+	if tm.read(AddrTAC) != 0xF9 {
+		t.Error(tm.read(AddrTAC))
+	}
+}
+
+// newOverflowTimer returns a timer whose TIMA overflows on the next
+// machine cycle.
+func newOverflowTimer() timer {
+	tm := timer{counter: 0x000C, tima: 0xFF, tma: 0x80, tac: 0x05}
+	return tm
+}
+
+func TestTimerReload(t *testing.T) {
+	// TIMA reads 0x00 for a machine cycle before TMA is loaded
+	tm := newOverflowTimer()
+	if tm.run(4) || tm.tima != 0x00 {
+		t.Error(tm)
+	}
+	if !tm.run(4) || tm.tima != 0x80 {
+		t.Error(tm)
+	}
+
+	// writing TIMA during the delay cancels the reload and the interrupt
+	tm = newOverflowTimer()
+	tm.run(4)
+	tm.write(AddrTIMA, 0x42)
+	if tm.run(4) || tm.tima != 0x42 {
+		t.Error(tm)
+	}
+
+	// writing TIMA on the reload cycle is ignored
+	tm = newOverflowTimer()
+	tm.run(8)
+	tm.write(AddrTIMA, 0x42)
+	if tm.tima != 0x80 {
+		t.Error(tm)
+	}
+
+	// writing TMA on the reload cycle also loads TIMA
+	tm = newOverflowTimer()
+	tm.run(8)
+	tm.write(AddrTMA, 0x33)
+	if tm.tima != 0x33 || tm.tma != 0x33 {
+		t.Error(tm)
+	}
+
+	// a machine cycle later TMA writes only go to TMA
+	tm.run(4)
+	tm.write(AddrTMA, 0x44)
+	if tm.tima != 0x33 || tm.tma != 0x44 {
+		t.Error(tm)
+	}
+}
+
+func TestTimerGlitch(t *testing.T) {
+	// resetting DIV with the selected bit set is a falling edge
+	tm := timer{counter: 0x0008, tac: 0x05}
+	tm.write(AddrDIV, 0x12)
+	if tm.tima != 1 || tm.counter != 0 {
+		t.Error(tm)
+	}
+	tm = timer{counter: 0x0004, tac: 0x05}
+	tm.write(AddrDIV, 0x12)
+	if tm.tima != 0 {
+		t.Error(tm)
+	}
+
+	// so is disabling the timer
+	tm = timer{counter: 0x0008, tac: 0x05}
+	tm.write(AddrTAC, 0x01)
+	if tm.tima != 1 {
+		t.Error(tm)
+	}
+
+	// or switching to a clear bit
+	tm = timer{counter: 0x0008, tac: 0x05}
+	tm.write(AddrTAC, 0x06)
+	if tm.tima != 1 {
+		t.Error(tm)
+	}
+}
+
+func TestTimerInterrupt(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{
+		0xE0, 0x05, // LDH (n), A
+		0x00, // NOP
+		0x00, // NOP
+	})
+	defer cpu.RunCommand(CmdStop, nil)
+	cpu.a.set(0xFF)
+	cpu.timer.tac = 0x05
+	cpu.timer.tma = 0xFE
+
+	// TIMA = 0xFF, overflows 4 cycles into the NOP
+	cpu.step(false, 0)
+	if cpu.peekByte(AddrTIMA) != 0xFF {
+		t.Error(cpu.peekByte(AddrTIMA))
+	}
+	cpu.step(false, 0)
+	if cpu.peekByte(AddrTIMA) != 0x00 || cpu.readIo(AddrIF) != 0 {
+		t.Error(cpu.peekByte(AddrTIMA))
+	}
+	cpu.step(false, 0)
+	if cpu.peekByte(AddrTIMA) != 0xFE {
+		t.Error(cpu.peekByte(AddrTIMA))
+	}
+	if cpu.mmu.ReadByteAt(AddrIF, 0) != Byte(InterruptTimer) {
+		t.Error(cpu.mmu.ReadByteAt(AddrIF, 0))
+	}
+}
@@ -0,0 +1,222 @@
+package jibi
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+// A TraceRecord is one executed instruction along with the registers as they
+// were before it ran.
+type TraceRecord struct {
+	PC     Word
+	Bank   uint16  // rom bank mapped at 0x4000
+	Opcode Byte    // 0xCB for prefixed instructions
+	Bytes  [3]Byte // opcode and operands, only the first Len are valid
+	Len    uint8
+	PCMem  [4]Byte // memory at PC, for comparing with other emulators
+	Registers
+}
+
+// Operands returns the bytes following the opcode.
+func (r TraceRecord) Operands() []Byte {
+	return r.Bytes[1:r.Len]
+}
+
+// A TraceFilter decides if a record is sent to a Tracer. Filters run on the
+// cpu goroutine so they must be cheap.
+type TraceFilter func(*TraceRecord) bool
+
+// TracePCRange keeps records with from <= PC <= to.
+func TracePCRange(from, to Word) TraceFilter {
+	return func(r *TraceRecord) bool {
+		return r.PC >= from && r.PC <= to
+	}
+}
+
+// TraceBank keeps records from the fixed bank and the given switchable bank.
+func TraceBank(bank uint16) TraceFilter {
+	return func(r *TraceRecord) bool {
+		return r.PC < 0x4000 || r.PC >= 0x8000 || r.Bank == bank
+	}
+}
+
+// TraceOpcodes keeps records of the given base opcodes.
+func TraceOpcodes(ops ...Byte) TraceFilter {
+	var set [256]bool
+	for _, op := range ops {
+		set[op] = true
+	}
+	return func(r *TraceRecord) bool {
+		return set[r.Opcode]
+	}
+}
+
+// A Tracer receives the records that pass all of its filters on C. The cpu
+// never waits on a Tracer, records that do not fit in C are dropped.
+type Tracer struct {
+	C       chan TraceRecord
+	filters []TraceFilter
+	dropped uint64
+}
+
+// Dropped returns the number of records lost because C was full.
+func (t *Tracer) Dropped() uint64 {
+	return atomic.LoadUint64(&t.dropped)
+}
+
+func (t *Tracer) send(r *TraceRecord) {
+	for _, f := range t.filters {
+		if !f(r) {
+			return
+		}
+	}
+	select {
+	case t.C <- *r:
+	default:
+		atomic.AddUint64(&t.dropped, 1)
+	}
+}
+
+// A TraceEncoder writes trace records to a stream.
+type TraceEncoder interface {
+	Encode(TraceRecord) error
+}
+
+// binaryTraceRecord is the fixed 32 byte little endian layout of the
+// binary encoding.
+type binaryTraceRecord struct {
+	PC                     Word
+	Bank                   uint16
+	Bytes                  [3]Byte
+	Len                    uint8
+	A, F, B, C, D, E, H, L Byte
+	SP                     Word
+	Flags                  Byte // bit 0 IME, bit 1 halted
+	PCMem                  [4]Byte
+	_                      Byte
+	Cycles                 uint64
+}
+
+const (
+	traceFlagIME Byte = 1 << iota
+	traceFlagHalted
+)
+
+type binaryTraceEncoder struct {
+	w io.Writer
+}
+
+// NewBinaryTraceEncoder returns an encoder that writes 32 byte records,
+// compact enough for long captures.
+func NewBinaryTraceEncoder(w io.Writer) TraceEncoder {
+	return binaryTraceEncoder{w}
+}
+
+func (e binaryTraceEncoder) Encode(r TraceRecord) error {
+	b := binaryTraceRecord{
+		PC: r.PC, Bank: r.Bank, Bytes: r.Bytes, Len: r.Len, PCMem: r.PCMem,
+		A: r.A, F: r.F, B: r.B, C: r.C, D: r.D, E: r.E, H: r.H, L: r.L,
+		SP: r.SP, Cycles: r.Cycles,
+	}
+	if r.IME {
+		b.Flags |= traceFlagIME
+	}
+	if r.Halted {
+		b.Flags |= traceFlagHalted
+	}
+	return binary.Write(e.w, binary.LittleEndian, &b)
+}
+
+// A BinaryTraceDecoder reads records written by a binary trace encoder.
+type BinaryTraceDecoder struct {
+	r io.Reader
+}
+
+// NewBinaryTraceDecoder returns a decoder reading from r.
+func NewBinaryTraceDecoder(r io.Reader) *BinaryTraceDecoder {
+	return &BinaryTraceDecoder{r}
+}
+
+// Decode reads the next record, it returns io.EOF at the end of the stream.
+func (d *BinaryTraceDecoder) Decode() (TraceRecord, error) {
+	var b binaryTraceRecord
+	if err := binary.Read(d.r, binary.LittleEndian, &b); err != nil {
+		return TraceRecord{}, err
+	}
+	return TraceRecord{
+		PC: b.PC, Bank: b.Bank, Opcode: b.Bytes[0], Bytes: b.Bytes, Len: b.Len,
+		PCMem: b.PCMem,
+		Registers: Registers{
+			A: b.A, F: b.F, B: b.B, C: b.C, D: b.D, E: b.E, H: b.H, L: b.L,
+			SP: b.SP, PC: b.PC,
+			IME:    b.Flags&traceFlagIME != 0,
+			Halted: b.Flags&traceFlagHalted != 0,
+			Cycles: b.Cycles,
+		},
+	}, nil
+}
+
+// jsonTraceRecord is the layout of a JSON-lines record.
+type jsonTraceRecord struct {
+	PC       Word   `json:"pc"`
+	Bank     uint16 `json:"bank"`
+	Opcode   Byte   `json:"op"`
+	Operands []int  `json:"operands"`
+	A        Byte   `json:"a"`
+	F        Byte   `json:"f"`
+	B        Byte   `json:"b"`
+	C        Byte   `json:"c"`
+	D        Byte   `json:"d"`
+	E        Byte   `json:"e"`
+	H        Byte   `json:"h"`
+	L        Byte   `json:"l"`
+	SP       Word   `json:"sp"`
+	IME      bool   `json:"ime"`
+	Halted   bool   `json:"halted"`
+	Cycles   uint64 `json:"cycles"`
+}
+
+type jsonTraceEncoder struct {
+	enc *json.Encoder
+}
+
+// NewJSONTraceEncoder returns an encoder that writes one JSON object per
+// line.
+func NewJSONTraceEncoder(w io.Writer) TraceEncoder {
+	return jsonTraceEncoder{json.NewEncoder(w)}
+}
+
+func (e jsonTraceEncoder) Encode(r TraceRecord) error {
+	operands := make([]int, 0, 2)
+	for _, b := range r.Operands() {
+		operands = append(operands, int(b))
+	}
+	return e.enc.Encode(jsonTraceRecord{
+		PC: r.PC, Bank: r.Bank, Opcode: r.Opcode, Operands: operands,
+		A: r.A, F: r.F, B: r.B, C: r.C, D: r.D, E: r.E, H: r.H, L: r.L,
+		SP: r.SP, IME: r.IME, Halted: r.Halted, Cycles: r.Cycles,
+	})
+}
+
+type doctorTraceEncoder struct {
+	w io.Writer
+}
+
+// NewDoctorTraceEncoder returns an encoder that writes the Gameboy Doctor
+// line format so runs can be diffed against reference logs.
+//
+//	A:01 F:B0 B:00 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0100 PCMEM:00,C3,13,02
+func NewDoctorTraceEncoder(w io.Writer) TraceEncoder {
+	return doctorTraceEncoder{w}
+}
+
+func (e doctorTraceEncoder) Encode(r TraceRecord) error {
+	_, err := fmt.Fprintf(e.w, "A:%02X F:%02X B:%02X C:%02X D:%02X E:%02X "+
+		"H:%02X L:%02X SP:%04X PC:%04X PCMEM:%02X,%02X,%02X,%02X\n",
+		r.A, r.F, r.B, r.C, r.D, r.E, r.H, r.L, r.SP, r.PC,
+		r.PCMem[0], r.PCMem[1], r.PCMem[2], r.PCMem[3])
+	return err
+}
@@ -0,0 +1,108 @@
+package jibi
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestTrace(t *testing.T) {
+	cpu := NewCpu(newTestMmu(), []Byte{
+		0x3E, 0x42, // 0x00 LD A, #
+		0xCB, 0x37, // 0x02 SWAP A
+		0xC3, 0x00, 0x00, // 0x04 JP nn
+	})
+	defer cpu.RunCommand(CmdStop, nil)
+	all := cpu.AttachTrace(3)
+	jumps := cpu.AttachTrace(8, TraceOpcodes(0xC3))
+	low := cpu.AttachTrace(8, TracePCRange(0x0000, 0x0002))
+
+	for i := 0; i < 6; i++ {
+		cpu.step(false, 0)
+	}
+	if len(all.C) != 3 || all.Dropped() != 3 {
+		t.Error(len(all.C), all.Dropped())
+	}
+	if len(jumps.C) != 2 || len(low.C) != 4 {
+		t.Error(len(jumps.C), len(low.C))
+	}
+
+	r := <-all.C
+	if r.PC != 0x0000 || r.Opcode != 0x3E || r.Len != 2 || r.A != 0x00 {
+		t.Errorf("%+v", r)
+	}
+	if ops := r.Operands(); len(ops) != 1 || ops[0] != 0x42 {
+		t.Error(ops)
+	}
+	r = <-all.C
+	if r.PC != 0x0002 || r.Bytes != [3]Byte{0xCB, 0x37, 0x00} || r.A != 0x42 {
+		t.Errorf("%+v", r)
+	}
+	if r.Cycles != 8 || r.Bank != 1 {
+		t.Errorf("%+v", r)
+	}
+}
+
+func TestTraceEncoders(t *testing.T) {
+	r := TraceRecord{PC: 0x0150, Bank: 2, Opcode: 0xC3,
+		Bytes: [3]Byte{0xC3, 0x50, 0x01}, Len: 3,
+		Registers: Registers{A: 0x01, F: 0xB0, SP: 0xFFFE, PC: 0x0150,
+			IME: true, Cycles: 1 << 40}}
+
+	var buf bytes.Buffer
+	enc := NewBinaryTraceEncoder(&buf)
+	enc.Encode(r)
+	enc.Encode(r)
+	if buf.Len() != 64 {
+		t.Fatal(buf.Len())
+	}
+	dec := NewBinaryTraceDecoder(&buf)
+	for i := 0; i < 2; i++ {
+		got, err := dec.Decode()
+		if err != nil || got != r {
+			t.Errorf("%+v %v", got, err)
+		}
+	}
+	if _, err := dec.Decode(); err != io.EOF {
+		t.Error(err)
+	}
+
+	var sb strings.Builder
+	NewJSONTraceEncoder(&sb).Encode(r)
+	expected := `{"pc":336,"bank":2,"op":195,"operands":[80,1],` +
+		`"a":1,"f":176,"b":0,"c":0,"d":0,"e":0,"h":0,"l":0,"sp":65534,` +
+		`"ime":true,"halted":false,"cycles":1099511627776}` + "\n"
+	if sb.String() != expected {
+		t.Error(sb.String())
+	}
+}
+
+func TestTraceDoctor(t *testing.T) {
+	mmu := newTestMmu()
+	for i, b := range []Byte{0x00, 0xC3, 0x13, 0x02} {
+		mmu.WriteByteAt(0x0100+Word(i), b, 0)
+	}
+	mmu.WriteByteAt(0x0213, 0xF0, 0) // LDH A, (n)
+	mmu.WriteByteAt(0x0214, 0x44, 0)
+	cpu := NewCpu(mmu, bios)
+	defer cpu.RunCommand(CmdStop, nil)
+	cpu.stubLY = true
+	cpu.postBoot()
+	tracer := cpu.AttachTrace(8)
+
+	var sb strings.Builder
+	enc := NewDoctorTraceEncoder(&sb)
+	for i := 0; i < 4; i++ {
+		cpu.step(false, 0)
+		enc.Encode(<-tracer.C)
+	}
+	expected := "" +
+		"A:01 F:B0 B:00 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0100 PCMEM:00,C3,13,02\n" +
+		"A:01 F:B0 B:00 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0101 PCMEM:C3,13,02,00\n" +
+		"A:01 F:B0 B:00 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0213 PCMEM:F0,44,00,00\n" +
+		"A:90 F:B0 B:00 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0215 PCMEM:00,00,00,00\n"
+	if sb.String() != expected {
+		t.Error(sb.String())
+	}
+}
@@ -0,0 +1,183 @@
+package jibi
+
+import (
+	"fmt"
+)
+
+// A WatchKind is a kind of memory access, kinds can be combined.
+type WatchKind uint8
+
+// Watchpoint kinds.
+const (
+	WatchRead WatchKind = 1 << iota
+	WatchWrite
+	WatchExecute
+)
+
+func (k WatchKind) String() string {
+	switch k {
+	case WatchRead:
+		return "read"
+	case WatchWrite:
+		return "write"
+	case WatchExecute:
+		return "execute"
+	}
+	return fmt.Sprintf("WatchKind(%d)", uint8(k))
+}
+
+// An Accessor is the component making a memory access.
+type Accessor uint8
+
+// Accessors, kept in the top bits of AddressKeys.
+const (
+	AccessCpu Accessor = iota
+	AccessGpu
+	AccessKeypad
+	AccessDma
+)
+
+func (a Accessor) String() string {
+	switch a {
+	case AccessCpu:
+		return "cpu"
+	case AccessGpu:
+		return "gpu"
+	case AccessKeypad:
+		return "keypad"
+	case AccessDma:
+		return "dma"
+	}
+	return "unknown"
+}
+
+const (
+	akAccessorShift             = 12
+	akAccessor      AddressKeys = 0x3 << akAccessorShift
+	akUnwatched     AddressKeys = 1 << 14 // bookkeeping watchpoints and strict mode skip
+	akFetch         AddressKeys = 1 << 15 // the cpu reads an opcode
+)
+
+// accessorKeys returns the keys a component starts with.
+func accessorKeys(a Accessor) AddressKeys {
+	return AddressKeys(a) << akAccessorShift
+}
+
+func (ak AddressKeys) accessor() Accessor {
+	return Accessor((ak & akAccessor) >> akAccessorShift)
+}
+
+// A MemoryAccess is an access that hit a watchpoint.
+type MemoryAccess struct {
+	Kind     WatchKind
+	Addr     Word
+	Value    Byte // read, written or the opcode executed
+	Old      Byte // before a write, the same as Value otherwise
+	PC       Word // instruction of a cpu or dma access, 0 otherwise
+	Accessor Accessor
+}
+
+func (a MemoryAccess) String() string {
+	return fmt.Sprintf("%s %s 0x%04X 0x%02X (was 0x%02X) at 0x%04X",
+		a.Accessor, a.Kind, a.Addr, a.Value, a.Old, a.PC)
+}
+
+// A Watchpoint calls Fn for accesses of Kind to From <= addr <= To. Fn runs
+// on the goroutine of the accessing component while it holds its memory
+// locks, it must be quick and must not access memory itself.
+//
+// Registers the cpu keeps itself, the timer, KEY1 and FF46, and reads of the
+// boot rom never reach the Mmu and are not seen.
+type Watchpoint struct {
+	From, To Word
+	Kind     WatchKind
+	Fn       func(MemoryAccess)
+}
+
+// watch is a registered Watchpoint.
+type watch struct {
+	id int
+	Watchpoint
+}
+
+// AddWatchpoint registers w and returns an id for RemoveWatchpoint.
+func (m *RomOnlyMmu) AddWatchpoint(w Watchpoint) int {
+	m.watchLock.Lock()
+	defer m.watchLock.Unlock()
+	m.nextWatch++
+	var ws []watch
+	if old := m.watches.Load(); old != nil {
+		ws = append(ws, *old...)
+	}
+	ws = append(ws, watch{m.nextWatch, w})
+	m.watches.Store(&ws)
+	return m.nextWatch
+}
+
+// RemoveWatchpoint unregisters a watchpoint, the callback can still run
+// once for an access already in progress.
+func (m *RomOnlyMmu) RemoveWatchpoint(id int) {
+	m.watchLock.Lock()
+	defer m.watchLock.Unlock()
+	old := m.watches.Load()
+	if old == nil {
+		return
+	}
+	var ws []watch
+	for _, w := range *old {
+		if w.id != id {
+			ws = append(ws, w)
+		}
+	}
+	if len(ws) == 0 {
+		m.watches.Store(nil)
+	} else {
+		m.watches.Store(&ws)
+	}
+}
+
+// Fetch reports an opcode fetch the cpu served without reading memory, as it
+// does while the dma holds the bus.
+func (m *RomOnlyMmu) Fetch(addr Word, b Byte, ak AddressKeys) {
+	m.watchRead(addr, b, ak|akFetch)
+}
+
+// watching returns the watchpoints of kind covering addr.
+func (m *RomOnlyMmu) watching(kind WatchKind, addr Word, ak AddressKeys) []watch {
+	ws := m.watches.Load()
+	if ws == nil || ak&akUnwatched != 0 {
+		return nil
+	}
+	var hits []watch
+	for _, w := range *ws {
+		if w.Kind&kind != 0 && w.From <= addr && addr <= w.To {
+			hits = append(hits, w)
+		}
+	}
+	return hits
+}
+
+// watchRead fires the watchpoints for a read, an opcode fetch is an execute
+// and starts a new instruction.
+func (m *RomOnlyMmu) watchRead(addr Word, b Byte, ak AddressKeys) {
+	kind := WatchRead
+	if ak&akFetch != 0 {
+		kind = WatchExecute
+		m.pc = addr
+	}
+	if hits := m.watching(kind, addr, ak); len(hits) > 0 {
+		m.fire(hits, MemoryAccess{Kind: kind, Addr: addr, Value: b, Old: b}, ak)
+	}
+}
+
+// fire calls the watchpoints with the access.
+func (m *RomOnlyMmu) fire(hits []watch, a MemoryAccess, ak AddressKeys) {
+	a.Accessor = ak.accessor()
+	if a.Accessor == AccessCpu || a.Accessor == AccessDma {
+		// only read on the cpu goroutine
+		a.PC = m.pc
+	}
+	for _, w := range hits {
+		w.Fn(a)
+	}
+}
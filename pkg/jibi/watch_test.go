@@ -0,0 +1,153 @@
+package jibi
+
+import (
+	"testing"
+)
+
+func TestWatchpoints(t *testing.T) {
+	j := newTestJibi([]Byte{
+		0x3E, 0x42, // 0x0100 LD A, 0x42
+		0xEA, 0x00, 0xC0, // 0x0102 LD (0xC000), A
+		0xFA, 0x00, 0xC0, // 0x0105 LD A, (0xC000)
+		0x3C,             // 0x0108 INC A
+		0xEA, 0x00, 0xC0, // 0x0109 LD (0xC000), A
+		0x18, 0xFE, // 0x010C JR -2
+	})
+	defer j.Stop()
+
+	var ram, code []MemoryAccess
+	id := j.AddWatchpoint(Watchpoint{0xC000, 0xC0FF, WatchRead | WatchWrite,
+		func(a MemoryAccess) { ram = append(ram, a) }})
+	j.AddWatchpoint(Watchpoint{0x0105, 0x0109, WatchExecute,
+		func(a MemoryAccess) { code = append(code, a) }})
+	for i := 0; i < 5; i++ {
+		j.Step()
+	}
+
+	want := []MemoryAccess{
+		{WatchWrite, 0xC000, 0x42, 0x00, 0x0102, AccessCpu},
+		{WatchRead, 0xC000, 0x42, 0x42, 0x0105, AccessCpu},
+		{WatchWrite, 0xC000, 0x43, 0x42, 0x0109, AccessCpu},
+	}
+	if len(ram) != len(want) {
+		t.Fatal(ram)
+	}
+	for i := range want {
+		if ram[i] != want[i] {
+			t.Error(ram[i], want[i])
+		}
+	}
+	if len(code) != 3 || code[0].Addr != 0x0105 || code[0].Value != 0xFA ||
+		code[2].Addr != 0x0109 || code[2].Kind != WatchExecute {
+		t.Error(code)
+	}
+
+	j.RemoveWatchpoint(id)
+	ram = nil
+	j.Step()
+	j.cpu.writeByte(0xC000, 0x00)
+	if len(ram) != 0 {
+		t.Error(ram)
+	}
+}
+
+func TestWatchpointAccessor(t *testing.T) {
+	mmu := NewMmu(nil)
+	var got []MemoryAccess
+	mmu.AddWatchpoint(Watchpoint{AddrOam, AddrOam, WatchWrite,
+		func(a MemoryAccess) { got = append(got, a) }})
+
+	for _, a := range []Accessor{AccessCpu, AccessGpu, AccessKeypad, AccessDma} {
+		ak := mmu.LockAddr(AddrOam, accessorKeys(a))
+		mmu.WriteByteAt(AddrOam, Byte(a), ak)
+		mmu.UnlockAddr(AddrOam, ak)
+	}
+	if len(got) != 4 {
+		t.Fatal(got)
+	}
+	for i, a := range got {
+		if a.Accessor != Accessor(i) || a.Value != Byte(i) {
+			t.Error(a)
+		}
+	}
+
+	// cpu bookkeeping is not reported
+	ak := mmu.LockAddr(AddrOam, akUnwatched)
+	mmu.WriteByteAt(AddrOam, 0x42, ak)
+	if len(got) != 4 {
+		t.Error(got[4:])
+	}
+}
+
+func TestWatchpointDma(t *testing.T) {
+	rom := make([]Byte, 0x8000)
+	copy(rom[0x0100:], []Byte{
+		0x3E, 0xC1, // 0x0100 LD A, 0xC1
+		0xCD, 0x80, 0xFF, // 0x0102 CALL 0xFF80
+		0xE0, 0x46, // 0x0105 LDH (0x46), A
+		0x00, // 0x0107 NOP, fetched from the dma source
+	})
+	j := New(rom, Options{Skipbios: true, Synchronous: true, CycleAccurate: true})
+	defer j.Stop()
+	for i, b := range []Byte{
+		0xE0, 0x46, // 0xFF80 LDH (0x46), A
+		0x3E, 0x28, // 0xFF82 LD A, 0x28
+		0x3D,       // 0xFF84 DEC A
+		0x20, 0xFD, // 0xFF85 JR NZ, -3
+		0x3E, 0xC1, // 0xFF87 LD A, 0xC1
+		0xC9, // 0xFF89 RET
+	} {
+		j.cpu.writeByte(0xFF80+Word(i), b)
+	}
+	for i := Word(0); i < dmaLength; i++ {
+		j.cpu.writeByte(0xC100+i, 0x00)
+	}
+
+	// the dma reads its source while the cpu fetches from hram
+	var src []MemoryAccess
+	j.AddWatchpoint(Watchpoint{0xC100, 0xC100 + dmaLength - 1,
+		WatchRead | WatchExecute, func(a MemoryAccess) { src = append(src, a) }})
+	for j.cpu.pc != 0x0105 {
+		j.Step()
+	}
+	if len(src) != dmaLength {
+		t.Fatal(len(src))
+	}
+	for _, a := range src {
+		if a.Kind != WatchRead || a.Accessor != AccessDma || a.PC < 0xFF80 || a.PC > 0xFF89 {
+			t.Fatal(a)
+		}
+	}
+
+	// a fetch from the bus the dma reads from still executes
+	var code []MemoryAccess
+	j.AddWatchpoint(Watchpoint{0x0107, 0x0107, WatchExecute,
+		func(a MemoryAccess) { code = append(code, a) }})
+	j.Step()
+	j.Step()
+	if len(code) != 1 || code[0].Addr != 0x0107 || code[0].Accessor != AccessCpu {
+		t.Error(code)
+	}
+}
+
+func TestWatchpointTrace(t *testing.T) {
+	j := newTestJibi([]Byte{
+		0x00, // 0x0100 NOP
+		0x00, // 0x0101 NOP
+	})
+	defer j.Stop()
+
+	// the tracer reads the memory at pc for its records
+	tracer := j.cpu.AttachTrace(8)
+	var got []MemoryAccess
+	j.AddWatchpoint(Watchpoint{0x0100, 0x0104, WatchRead,
+		func(a MemoryAccess) { got = append(got, a) }})
+	j.Step()
+	j.Step()
+	if len(tracer.C) != 2 {
+		t.Fatal(len(tracer.C))
+	}
+	if len(got) != 0 {
+		t.Error(got)
+	}
+}